@@ -0,0 +1,83 @@
+// Scrape audit log plugin status variables.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// Subsystem.
+	auditLog = "audit_log"
+	// Queries.
+	auditLogPluginQuery = `
+		SELECT COUNT(*)
+		  FROM information_schema.plugins
+		  WHERE PLUGIN_NAME = 'audit_log' AND PLUGIN_STATUS = 'ACTIVE'
+		`
+	auditLogStatusQuery = `SHOW GLOBAL STATUS LIKE 'Audit_log_%'`
+)
+
+// Map known audit log status variables to types. Unknown variables will be
+// mapped as untyped.
+var auditLogStatusTypes = map[string]prometheus.ValueType{
+	"buffer_size_overflow": prometheus.CounterValue,
+	"current_size":         prometheus.GaugeValue,
+	"event_max_drop_size":  prometheus.GaugeValue,
+	"events":               prometheus.CounterValue,
+	"events_filtered":      prometheus.CounterValue,
+	"events_lost":          prometheus.CounterValue,
+	"events_written":       prometheus.CounterValue,
+	"total_size":           prometheus.CounterValue,
+	"write_waits":          prometheus.CounterValue,
+}
+
+// ScrapeAuditLog collects `Audit_log_*` status variables when the audit log plugin is active.
+func ScrapeAuditLog(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var plugins uint8
+	if err := db.QueryRow(auditLogPluginQuery).Scan(&plugins); err != nil {
+		return err
+	}
+	if plugins == 0 {
+		log.Debugln("Audit log plugin is not active.")
+		return nil
+	}
+
+	auditLogRows, err := db.Query(auditLogStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer auditLogRows.Close()
+
+	var key string
+	var val sql.RawBytes
+
+	for auditLogRows.Next() {
+		if err := auditLogRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok { // Unparsable values are silently skipped.
+			continue
+		}
+		key = strings.TrimPrefix(strings.ToLower(key), "audit_log_")
+		valueType, ok := auditLogStatusTypes[key]
+		if !ok {
+			valueType = prometheus.UntypedValue
+		}
+		name := key
+		if valueType == prometheus.CounterValue {
+			name += "_total"
+		}
+		ch <- prometheus.MustNewConstMetric(
+			newDesc(auditLog, name, "Audit log plugin status variable Audit_log_"+key+"."),
+			valueType,
+			floatVal,
+		)
+	}
+	return nil
+}
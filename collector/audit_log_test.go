@@ -0,0 +1,88 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeAuditLog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(auditLogPluginQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Audit_log_current_size", "4096").
+		AddRow("Audit_log_events", "120").
+		AddRow("Audit_log_events_filtered", "20").
+		AddRow("Audit_log_events_lost", "3").
+		AddRow("Audit_log_events_written", "97").
+		AddRow("Audit_log_write_waits", "5").
+		AddRow("Audit_log_something_new", "6")
+	mock.ExpectQuery(sanitizeQuery(auditLogStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeAuditLog(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 4096, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 120, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 20, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 97, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 6, metricType: dto.MetricType_UNTYPED},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeAuditLogNoPlugin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(auditLogPluginQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeAuditLog(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without the plugin", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
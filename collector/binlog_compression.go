@@ -0,0 +1,91 @@
+// Scrape binary log transaction compression statistics from
+// `performance_schema.binary_log_transaction_compression_stats`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const binlogCompressionQuery = `
+	SELECT LOG_TYPE, COMPRESSION_TYPE, TRANSACTION_COUNTER,
+	       COMPRESSED_BYTES_COUNTER, UNCOMPRESSED_BYTES_COUNTER
+	  FROM performance_schema.binary_log_transaction_compression_stats
+	`
+
+// Metric descriptors.
+var (
+	binlogCompressionTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "compression_transactions_total"),
+		"The number of transactions written to the log with the compression type.",
+		[]string{"log_type", "compression_type"}, nil,
+	)
+	binlogCompressionCompressedBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "compression_compressed_bytes_total"),
+		"The number of bytes of transactions written to the log after compression.",
+		[]string{"log_type", "compression_type"}, nil,
+	)
+	binlogCompressionUncompressedBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "compression_uncompressed_bytes_total"),
+		"The number of bytes of transactions written to the log before compression.",
+		[]string{"log_type", "compression_type"}, nil,
+	)
+	binlogCompressionRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "compression_ratio"),
+		"Ratio of compressed to uncompressed bytes of the transactions written to the log.",
+		[]string{"log_type", "compression_type"}, nil,
+	)
+)
+
+// ScrapeBinlogCompression collects binary log transaction compression
+// statistics, available from MySQL 8.0.20.
+func ScrapeBinlogCompression(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "binary_log_transaction_compression_stats")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.binary_log_transaction_compression_stats is not available.")
+		return nil
+	}
+
+	compressionRows, err := db.Query(binlogCompressionQuery)
+	if err != nil {
+		return err
+	}
+	defer compressionRows.Close()
+
+	var (
+		logType, compressionType                         string
+		transactions, compressedBytes, uncompressedBytes float64
+	)
+	for compressionRows.Next() {
+		if err := compressionRows.Scan(
+			&logType, &compressionType, &transactions, &compressedBytes, &uncompressedBytes,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			binlogCompressionTransactionsDesc, prometheus.CounterValue, transactions,
+			logType, compressionType,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			binlogCompressionCompressedBytesDesc, prometheus.CounterValue, compressedBytes,
+			logType, compressionType,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			binlogCompressionUncompressedBytesDesc, prometheus.CounterValue, uncompressedBytes,
+			logType, compressionType,
+		)
+		if uncompressedBytes > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				binlogCompressionRatioDesc, prometheus.GaugeValue, compressedBytes/uncompressedBytes,
+				logType, compressionType,
+			)
+		}
+	}
+	return compressionRows.Err()
+}
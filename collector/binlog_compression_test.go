@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeBinlogCompression(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "binary_log_transaction_compression_stats").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"LOG_TYPE", "COMPRESSION_TYPE", "TRANSACTION_COUNTER", "COMPRESSED_BYTES_COUNTER", "UNCOMPRESSED_BYTES_COUNTER"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("BINARY", "ZSTD", 1000, 25000, 100000).
+		AddRow("BINARY", "NONE", 0, 0, 0)
+	mock.ExpectQuery(sanitizeQuery(binlogCompressionQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeBinlogCompression(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	zstd := labelMap{"log_type": "BINARY", "compression_type": "ZSTD"}
+	none := labelMap{"log_type": "BINARY", "compression_type": "NONE"}
+	metricExpected := []MetricResult{
+		{labels: zstd, value: 1000, metricType: dto.MetricType_COUNTER},
+		{labels: zstd, value: 25000, metricType: dto.MetricType_COUNTER},
+		{labels: zstd, value: 100000, metricType: dto.MetricType_COUNTER},
+		{labels: zstd, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: none, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: none, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: none, value: 0, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Scrape replication propagation latency through a canary table.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// canary is the Metric subsystem we use.
+	canary = "canary"
+	// CanaryRoleRead reads the canary table, on a replica.
+	CanaryRoleRead = "read"
+	// CanaryRoleWrite writes the canary table, on a primary.
+	CanaryRoleWrite = "write"
+	// canaryNowQuery fetches the current server timestamp.
+	canaryNowQuery = "SELECT UNIX_TIMESTAMP(NOW(6))"
+	// canaryReadOnlyQuery checks whether the server accepts writes.
+	canaryReadOnlyQuery = "SELECT @@global.read_only"
+	// canaryWriteQuery stores a timestamp. %s will be replaced by the
+	// database and table name.
+	canaryWriteQuery = "REPLACE INTO `%s`.`%s` (id, ts) VALUES (1, ?)"
+	// canaryReadQuery fetches the current server timestamp along with the
+	// stored one. %s will be replaced by the database and table name.
+	canaryReadQuery = "SELECT UNIX_TIMESTAMP(NOW(6)), ts FROM `%s`.`%s` WHERE id = 1"
+)
+
+// Metric descriptors.
+var (
+	canaryWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, canary, "written_timestamp_seconds"),
+		"Timestamp last written to the canary table.",
+		nil, nil,
+	)
+	canaryLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, canary, "propagation_seconds"),
+		"Time since the timestamp read from the canary table was written on the primary.",
+		nil, nil,
+	)
+)
+
+// ScrapeCanary measures replication propagation latency through a canary
+// table. With the write role the current timestamp is written to the table,
+// which is skipped on read-only servers; with the read role the age of the
+// replicated timestamp is reported. The table is expected to look like:
+//
+//	CREATE TABLE canary (
+//	  id  int unsigned NOT NULL PRIMARY KEY,
+//	  ts  decimal(20,6) NOT NULL
+//	);
+func ScrapeCanary(db *sql.DB, ch chan<- prometheus.Metric, database, table, role string) error {
+	switch role {
+	case CanaryRoleWrite:
+		return writeCanary(db, ch, database, table)
+	case CanaryRoleRead:
+		return readCanary(db, ch, database, table)
+	default:
+		return fmt.Errorf("unknown canary role %q", role)
+	}
+}
+
+func writeCanary(db *sql.DB, ch chan<- prometheus.Metric, database, table string) error {
+	var readOnly bool
+	if err := db.QueryRow(canaryReadOnlyQuery).Scan(&readOnly); err != nil {
+		return err
+	}
+	if readOnly {
+		log.Debugln("Not writing the canary table of a read-only server.")
+		return nil
+	}
+
+	var now float64
+	if err := db.QueryRow(canaryNowQuery).Scan(&now); err != nil {
+		return err
+	}
+	if _, err := db.Exec(fmt.Sprintf(canaryWriteQuery, database, table), now); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(canaryWrittenDesc, prometheus.GaugeValue, now)
+	return nil
+}
+
+func readCanary(db *sql.DB, ch chan<- prometheus.Metric, database, table string) error {
+	var now, ts float64
+	err := db.QueryRow(fmt.Sprintf(canaryReadQuery, database, table)).Scan(&now, &ts)
+	if err == sql.ErrNoRows {
+		log.Debugln("The canary table has not been written yet.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(canaryLatencyDesc, prometheus.GaugeValue, now-ts)
+	return nil
+}
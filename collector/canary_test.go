@@ -0,0 +1,113 @@
+package collector
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// capturedArg is a sqlmock.Argument recording the value it is matched with.
+type capturedArg struct {
+	value driver.Value
+}
+
+func (a *capturedArg) Match(v driver.Value) bool {
+	a.value = v
+	return true
+}
+
+func TestScrapeCanary(t *testing.T) {
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer replica.Close()
+
+	// The primary writes its current timestamp.
+	written := &capturedArg{}
+	primaryMock.ExpectQuery(sanitizeQuery(canaryReadOnlyQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(0))
+	primaryMock.ExpectQuery(sanitizeQuery(canaryNowQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow("1500000000.5"))
+	primaryMock.ExpectExec(sanitizeQuery(fmt.Sprintf(canaryWriteQuery, "heartbeat", "canary"))).
+		WithArgs(written).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeCanary(primary, ch, "heartbeat", "canary", CanaryRoleWrite); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Primary metrics", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 1500000000.5, metricType: dto.MetricType_GAUGE})
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// The replica reads what the primary wrote, a quarter second later.
+	replicaMock.ExpectQuery(sanitizeQuery(fmt.Sprintf(canaryReadQuery, "heartbeat", "canary"))).
+		WillReturnRows(sqlmock.NewRows([]string{"", "ts"}).AddRow("1500000000.75", written.value))
+
+	ch = make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeCanary(replica, ch, "heartbeat", "canary", CanaryRoleRead); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Replica metrics", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE})
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeCanaryReadOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(canaryReadOnlyQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeCanary(db, ch, "heartbeat", "canary", CanaryRoleWrite); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Read-only servers are not written", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -3,8 +3,10 @@ package collector
 import (
 	"bytes"
 	"database/sql"
+	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -17,6 +19,33 @@ const (
 	// Query to check whether user/table/client stats are enabled.
 	userstatCheckQuery = `SHOW VARIABLES WHERE Variable_Name='userstat'
 		OR Variable_Name='userstat_running'`
+	// Query to check whether a table exists, e.g. on older server versions.
+	tableExistsQuery = `
+		SELECT COUNT(*)
+		  FROM information_schema.tables
+		  WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+		`
+	// Query to check whether a column exists, e.g. on older server versions.
+	columnExistsQuery = `
+		SELECT COUNT(*)
+		  FROM information_schema.columns
+		  WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND COLUMN_NAME = ?
+		`
+	// Query to check whether performance_schema is enabled.
+	perfSchemaEnabledQuery = `SELECT @@performance_schema`
+	// Query to count the enabled performance_schema consumers of a list.
+	consumersEnabledQuery = `
+		SELECT COUNT(*)
+		  FROM performance_schema.setup_consumers
+		  WHERE ENABLED = 'YES' AND NAME IN (%s)
+		`
+	// Query to count the enabled and timed performance_schema instruments
+	// matching a LIKE pattern.
+	instrumentsEnabledQuery = `
+		SELECT COUNT(*)
+		  FROM performance_schema.setup_instruments
+		  WHERE NAME LIKE ? AND ENABLED = 'YES' AND TIMED = 'YES'
+		`
 )
 
 var logRE = regexp.MustCompile(`.+\.(\d+)$`)
@@ -53,3 +82,107 @@ func parseStatus(data sql.RawBytes) (float64, bool) {
 	value, err := strconv.ParseFloat(string(data), 64)
 	return value, err == nil
 }
+
+// tableExists checks whether the given table is available on the server.
+func tableExists(db *sql.DB, schema, table string) (bool, error) {
+	var count uint8
+	if err := db.QueryRow(tableExistsQuery, schema, table).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// columnExists checks whether the given column is available on the server.
+func columnExists(db *sql.DB, schema, table, column string) (bool, error) {
+	var count uint8
+	if err := db.QueryRow(columnExistsQuery, schema, table, column).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// perfSchemaTableAvailable checks whether performance_schema is enabled and
+// has the given table.
+func perfSchemaTableAvailable(db *sql.DB, table string) (bool, error) {
+	var enabled bool
+	if err := db.QueryRow(perfSchemaEnabledQuery).Scan(&enabled); err != nil {
+		return false, err
+	}
+	if !enabled {
+		return false, nil
+	}
+	return tableExists(db, "performance_schema", table)
+}
+
+// consumersEnabled checks whether all the given performance_schema consumers
+// are enabled.
+func consumersEnabled(db *sql.DB, consumers ...string) (bool, error) {
+	placeholders, args := listArgs(strings.Join(consumers, ","))
+	var count int
+	if err := db.QueryRow(fmt.Sprintf(consumersEnabledQuery, placeholders), args...).Scan(&count); err != nil {
+		return false, err
+	}
+	return count == len(consumers), nil
+}
+
+// instrumentsEnabled checks whether any performance_schema instrument whose
+// name starts with prefix is enabled and timed.
+func instrumentsEnabled(db *sql.DB, prefix string) (bool, error) {
+	var count int
+	if err := db.QueryRow(instrumentsEnabledQuery, prefix+"%").Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// databaseFilter returns a condition, joined with the given keyword, that
+// restricts TABLE_SCHEMA to a single database along with its query arguments.
+// An empty database yields no condition.
+func databaseFilter(keyword, database string) (string, []interface{}) {
+	if database == "" {
+		return "", nil
+	}
+	return keyword + " TABLE_SCHEMA = ?", []interface{}{database}
+}
+
+// variableNameFilter returns a WHERE clause restricting the variable names in
+// column to those matching any of the comma separated LIKE patterns of include
+// and none of exclude, along with its query arguments. Empty lists yield no
+// condition.
+func variableNameFilter(column, include, exclude string) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if include != "" {
+		_, includeArgs := listArgs(include)
+		likes := make([]string, len(includeArgs))
+		for i := range likes {
+			likes[i] = column + " LIKE ?"
+		}
+		conditions = append(conditions, "("+strings.Join(likes, " OR ")+")")
+		args = append(args, includeArgs...)
+	}
+	if exclude != "" {
+		_, excludeArgs := listArgs(exclude)
+		for range excludeArgs {
+			conditions = append(conditions, column+" NOT LIKE ?")
+		}
+		args = append(args, excludeArgs...)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// listArgs splits a comma separated list into query arguments, returning them
+// along with the matching placeholders for use in an IN (...) clause.
+func listArgs(list string) (string, []interface{}) {
+	items := strings.Split(list, ",")
+	args := make([]interface{}, len(items))
+	for i, item := range items {
+		args[i] = strings.TrimSpace(item)
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(items)), ","), args
+}
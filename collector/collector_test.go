@@ -39,5 +39,7 @@ func sanitizeQuery(q string) string {
 	q = strings.Replace(q, "(", "\\(", -1)
 	q = strings.Replace(q, ")", "\\)", -1)
 	q = strings.Replace(q, "*", "\\*", -1)
+	q = strings.Replace(q, "?", "\\?", -1)
+	q = strings.Replace(q, "+", "\\+", -1)
 	return q
 }
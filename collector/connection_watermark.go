@@ -0,0 +1,81 @@
+// Scrape the peak connection usage since server start from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	connections = "connections"
+	// Queries.
+	connectionWatermarkSettingsQuery = `SELECT @@max_connections, TIMESTAMPDIFF(SECOND, UTC_TIMESTAMP(), NOW())`
+	connectionWatermarkStatusQuery   = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Max_used_connections', 'Max_used_connections_time')
+		`
+	// maxUsedConnectionsTimeLayout is the format of Max_used_connections_time,
+	// in the server's time zone.
+	maxUsedConnectionsTimeLayout = "2006-01-02 15:04:05"
+)
+
+// Metric descriptors.
+var (
+	connectionsMaxUsedRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connections, "max_used_ratio"),
+		"Ratio of the maximum number of connections in use simultaneously since the server started to max_connections.",
+		nil, nil,
+	)
+	connectionsMaxUsedTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connections, "max_used_timestamp_seconds"),
+		"When the maximum number of connections in use simultaneously was reached.",
+		nil, nil,
+	)
+)
+
+// ScrapeConnectionWatermark collects how close the server came to
+// max_connections since it started, and when.
+func ScrapeConnectionWatermark(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var maxConnections, utcOffset int
+	if err := db.QueryRow(connectionWatermarkSettingsQuery).Scan(&maxConnections, &utcOffset); err != nil {
+		return err
+	}
+
+	statusRows, err := db.Query(connectionWatermarkStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var key, val string
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		switch key {
+		case "Max_used_connections":
+			maxUsed, err := strconv.ParseFloat(val, 64)
+			if err != nil || maxConnections <= 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				connectionsMaxUsedRatioDesc, prometheus.GaugeValue, maxUsed/float64(maxConnections),
+			)
+		case "Max_used_connections_time":
+			// Empty until the first connection after a FLUSH STATUS.
+			peak, err := time.ParseInLocation(maxUsedConnectionsTimeLayout, val, time.FixedZone("", utcOffset))
+			if err != nil {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				connectionsMaxUsedTimeDesc, prometheus.GaugeValue, float64(peak.Unix()),
+			)
+		}
+	}
+	return statusRows.Err()
+}
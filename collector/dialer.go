@@ -0,0 +1,160 @@
+package collector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// DialerNetwork is the network name of DSNs whose connections are made
+// through the registered Dialer, e.g. "user:pass@exporter(db:3306)/".
+const DialerNetwork = "exporter"
+
+// Dialer opens the network connections to the MySQL server, e.g. to reach a
+// server only available through an SSH tunnel or a SOCKS proxy.
+type Dialer interface {
+	// Dial connects to the host:port address of the DSN.
+	Dial(addr string) (net.Conn, error)
+}
+
+// DialerFunc adapts an ordinary function to the Dialer interface.
+type DialerFunc func(addr string) (net.Conn, error)
+
+// Dial calls f(addr).
+func (f DialerFunc) Dial(addr string) (net.Conn, error) {
+	return f(addr)
+}
+
+// DefaultDialer connects directly over TCP.
+var DefaultDialer Dialer = DialerFunc(func(addr string) (net.Conn, error) {
+	return net.Dial("tcp", addr)
+})
+
+func init() {
+	RegisterDialer(DefaultDialer)
+}
+
+// RegisterDialer routes the connections of DialerNetwork DSNs through d. It
+// must be called before the first scrape.
+func RegisterDialer(d Dialer) {
+	mysql.RegisterDial(DialerNetwork, d.Dial)
+}
+
+// DialerDSN rewrites a TCP DSN to connect through the registered Dialer.
+// Other DSNs, e.g. unix sockets, are returned unchanged.
+func DialerDSN(dsn string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Net != "tcp" {
+		return dsn, nil
+	}
+	cfg.Net = DialerNetwork
+	return cfg.FormatDSN(), nil
+}
+
+// socks5HandshakeTimeout bounds the negotiation with a SOCKS5 proxy.
+const socks5HandshakeTimeout = 10 * time.Second
+
+// SOCKS5 protocol constants, see RFC 1928.
+const (
+	socks5Version      = 5
+	socks5NoAuth       = 0
+	socks5Connect      = 1
+	socks5AddrIPv4     = 1
+	socks5AddrDomain   = 3
+	socks5AddrIPv6     = 4
+	socks5ReplySuccess = 0
+)
+
+// SOCKS5Dialer returns a Dialer connecting through the SOCKS5 proxy at the
+// host:port proxyAddr. Only proxies not requiring authentication are
+// supported.
+func SOCKS5Dialer(proxyAddr string) Dialer {
+	return DialerFunc(func(addr string) (net.Conn, error) {
+		conn, err := net.Dial("tcp", proxyAddr)
+		if err != nil {
+			return nil, err
+		}
+		conn.SetDeadline(time.Now().Add(socks5HandshakeTimeout))
+		if err := socks5Handshake(conn, addr); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("socks5 proxy %s: %s", proxyAddr, err)
+		}
+		conn.SetDeadline(time.Time{})
+		return conn, nil
+	})
+}
+
+// socks5Handshake asks the proxy on conn to connect to the host:port addr.
+func socks5Handshake(conn io.ReadWriter, addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", portStr)
+	}
+
+	if _, err := conn.Write([]byte{socks5Version, 1, socks5NoAuth}); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != socks5Version || reply[1] != socks5NoAuth {
+		return fmt.Errorf("authentication required")
+	}
+
+	req := []byte{socks5Version, socks5Connect, 0}
+	if ip := net.ParseIP(host); ip != nil && ip.To4() != nil {
+		req = append(append(req, socks5AddrIPv4), ip.To4()...)
+	} else if ip != nil {
+		req = append(append(req, socks5AddrIPv6), ip.To16()...)
+	} else if len(host) > 255 {
+		return fmt.Errorf("host name %q too long", host)
+	} else {
+		req = append(append(req, socks5AddrDomain, byte(len(host))), host...)
+	}
+	req = append(req, 0, 0)
+	binary.BigEndian.PutUint16(req[len(req)-2:], uint16(port))
+	if _, err := conn.Write(req); err != nil {
+		return err
+	}
+
+	// The reply ends in the address bound by the proxy, which is skipped.
+	reply = make([]byte, 4)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != socks5Version {
+		return fmt.Errorf("unexpected version %d", reply[0])
+	}
+	if reply[1] != socks5ReplySuccess {
+		return fmt.Errorf("connect to %s failed with reply %d", addr, reply[1])
+	}
+	var boundLen int
+	switch reply[3] {
+	case socks5AddrIPv4:
+		boundLen = net.IPv4len
+	case socks5AddrIPv6:
+		boundLen = net.IPv6len
+	case socks5AddrDomain:
+		if _, err := io.ReadFull(conn, reply[:1]); err != nil {
+			return err
+		}
+		boundLen = int(reply[0])
+	default:
+		return fmt.Errorf("unknown address type %d", reply[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, boundLen+2))
+	return err
+}
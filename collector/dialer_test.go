@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestDialer(t *testing.T) {
+	var dialed []string
+	RegisterDialer(DialerFunc(func(addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return nil, errors.New("tunnel closed")
+	}))
+	defer RegisterDialer(DefaultDialer)
+
+	convey.Convey("TCP DSNs are routed through the dialer", t, func() {
+		dsn, err := DialerDSN("root:secret@tcp(db.internal:3307)/")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsn, convey.ShouldStartWith, "root:secret@exporter(db.internal:3307)/")
+
+		conn, err := sql.Open("mysql", dsn)
+		convey.So(err, convey.ShouldBeNil)
+		defer conn.Close()
+		convey.So(conn.Ping(), convey.ShouldNotBeNil)
+		convey.So(dialed, convey.ShouldNotBeEmpty)
+		convey.So(dialed[0], convey.ShouldEqual, "db.internal:3307")
+	})
+
+	convey.Convey("Unix socket DSNs are unchanged", t, func() {
+		dsn, err := DialerDSN("root@unix(/var/run/mysqld/mysqld.sock)/")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsn, convey.ShouldEqual, "root@unix(/var/run/mysqld/mysqld.sock)/")
+	})
+}
+
+func TestSOCKS5Dialer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// A proxy echoing the data of its connections, refusing those to port 1.
+	requestCh := make(chan []byte, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 3)
+			io.ReadFull(conn, buf)
+			conn.Write([]byte{5, 0})
+			req := make([]byte, 5)
+			io.ReadFull(conn, req)
+			rest := make([]byte, int(req[4])+2)
+			io.ReadFull(conn, rest)
+			requestCh <- append(req, rest...)
+			if rest[len(rest)-1] == 1 {
+				conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+				conn.Close()
+				continue
+			}
+			conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0x1f, 0x90})
+			io.Copy(conn, conn)
+			conn.Close()
+		}
+	}()
+
+	dialer := SOCKS5Dialer(listener.Addr().String())
+	convey.Convey("Connections are made through the proxy", t, func() {
+		conn, err := dialer.Dial("db.internal:3307")
+		convey.So(err, convey.ShouldBeNil)
+		defer conn.Close()
+		convey.So(<-requestCh, convey.ShouldResemble, append([]byte{5, 1, 0, 3, 11}, "db.internal\x0c\xeb"...))
+
+		_, err = conn.Write([]byte("ping"))
+		convey.So(err, convey.ShouldBeNil)
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(string(buf), convey.ShouldEqual, "ping")
+	})
+
+	convey.Convey("Refused connections are an error", t, func() {
+		_, err := dialer.Dial("db.internal:1")
+		<-requestCh
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
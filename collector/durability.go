@@ -0,0 +1,77 @@
+// Scrape durability related settings from `SHOW GLOBAL VARIABLES`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	durability = "durability"
+	// Query.
+	durabilitySettingsQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN (
+		    'sync_binlog', 'innodb_flush_log_at_trx_commit',
+		    'rpl_semi_sync_master_enabled', 'rpl_semi_sync_source_enabled',
+		    'slave_preserve_commit_order', 'replica_preserve_commit_order'
+		  )
+		`
+)
+
+// durabilityAliases maps the variable names introduced by MySQL 8.0.26 to
+// their older names, so that the metrics do not change with the version.
+var durabilityAliases = map[string]string{
+	"rpl_semi_sync_source_enabled":  "rpl_semi_sync_master_enabled",
+	"replica_preserve_commit_order": "slave_preserve_commit_order",
+}
+
+// Metric descriptors.
+var durabilityDescs = map[string]*prometheus.Desc{
+	"sync_binlog": newDesc(durability, "sync_binlog",
+		"Number of binary log commit groups collected before synchronizing to disk, 0 disables synchronization."),
+	"innodb_flush_log_at_trx_commit": newDesc(durability, "innodb_flush_log_at_trx_commit",
+		"How InnoDB writes and flushes the redo log at commit, 1 is fully durable."),
+	"rpl_semi_sync_master_enabled": newDesc(durability, "rpl_semi_sync_master_enabled",
+		"Whether semisynchronous replication is enabled on the source (1 for ON, 0 for OFF)."),
+	"slave_preserve_commit_order": newDesc(durability, "slave_preserve_commit_order",
+		"Whether multi-threaded replicas commit in the source's order (1 for ON, 0 for OFF)."),
+}
+
+// ScrapeDurabilitySettings collects durability related settings from
+// `SHOW GLOBAL VARIABLES`. Settings the server does not have, e.g. without the
+// semisynchronous replication plugin, are skipped.
+func ScrapeDurabilitySettings(db *sql.DB, ch chan<- prometheus.Metric) error {
+	durabilityRows, err := db.Query(durabilitySettingsQuery)
+	if err != nil {
+		return err
+	}
+	defer durabilityRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+
+	for durabilityRows.Next() {
+		if err := durabilityRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		key = strings.ToLower(key)
+		if alias, ok := durabilityAliases[key]; ok {
+			key = alias
+		}
+		desc, ok := durabilityDescs[key]
+		if !ok {
+			continue
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, floatVal)
+		}
+	}
+	return durabilityRows.Err()
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeDurabilitySettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("innodb_flush_log_at_trx_commit", "2").
+		AddRow("replica_preserve_commit_order", "ON").
+		AddRow("rpl_semi_sync_source_enabled", "OFF").
+		AddRow("sync_binlog", "0")
+	mock.ExpectQuery(sanitizeQuery(durabilitySettingsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeDurabilitySettings(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []struct {
+		desc   *prometheus.Desc
+		result MetricResult
+	}{
+		{durabilityDescs["innodb_flush_log_at_trx_commit"], MetricResult{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE}},
+		{durabilityDescs["slave_preserve_commit_order"], MetricResult{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE}},
+		{durabilityDescs["rpl_semi_sync_master_enabled"], MetricResult{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE}},
+		{durabilityDescs["sync_binlog"], MetricResult{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE}},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			m := <-ch
+			convey.So(m.Desc(), convey.ShouldEqual, expect.desc)
+			convey.So(readMetric(m), convey.ShouldResemble, expect.result)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -1,7 +1,11 @@
 package collector
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -9,6 +13,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
 )
 
 // Metric name parts.
@@ -21,6 +26,7 @@ const (
 const (
 	sessionSettingsQuery = `SET SESSION log_slow_filter = 'tmp_table_on_disk,filesort_on_disk'`
 	upQuery              = `SELECT 1`
+	databaseExistsQuery  = `SELECT COUNT(*) FROM information_schema.schemata WHERE SCHEMA_NAME = ?`
 )
 
 // Metric descriptors.
@@ -33,41 +39,181 @@ var (
 		"Collector time duration.",
 		[]string{"collector"}, nil,
 	)
+	collectorsEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "collectors_enabled"),
+		"Number of collectors run by the last scrape.",
+		nil, nil,
+	)
+	collectorsSucceededDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "collectors_succeeded"),
+		"Number of collectors of the last scrape that succeeded.",
+		nil, nil,
+	)
+	collectorsFailedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "collectors_failed"),
+		"Number of collectors of the last scrape that failed.",
+		nil, nil,
+	)
+)
+
+// Truncated collectors, kept across scrapes as the exporter is created anew
+// for every one of them.
+var (
+	collectorTruncated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: exporter,
+		Name:      "collector_truncated_total",
+		Help:      "Total number of times a collector emitted more metrics than allowed and was truncated.",
+	}, []string{"collector"})
+	truncatedWarnedMtx sync.Mutex
+	truncatedWarned    = map[string]bool{}
 )
 
 // Collect defines which metrics we should collect
 type Collect struct {
-	SlowLogFilter        bool
-	Processlist          bool
-	TableSchema          bool
-	InnodbTablespaces    bool
-	InnodbMetrics        bool
-	GlobalStatus         bool
-	GlobalVariables      bool
-	SlaveStatus          bool
-	AutoIncrementColumns bool
-	BinlogSize           bool
-	PerfTableIOWaits     bool
-	PerfIndexIOWaits     bool
-	PerfTableLockWaits   bool
-	PerfEventsStatements bool
-	PerfEventsWaits      bool
-	PerfFileEvents       bool
-	PerfFileInstances    bool
-	UserStat             bool
-	ClientStat           bool
-	TableStat            bool
-	QueryResponseTime    bool
-	EngineTokudbStatus   bool
-	EngineInnodbStatus   bool
-	Heartbeat            bool
-	HeartbeatDatabase    string
-	HeartbeatTable       string
-	MaxMySQLConns        int
+	SlowLogFilter             bool
+	Processlist               bool
+	TableSchema               bool
+	InnodbTablespaces         bool
+	InnodbMetrics             bool
+	GlobalStatus              bool
+	GlobalVariables           bool
+	SlaveStatus               bool
+	AutoIncrementColumns      bool
+	BinlogSize                bool
+	PerfTableIOWaits          bool
+	PerfIndexIOWaits          bool
+	PerfTableLockWaits        bool
+	PerfEventsStatements      bool
+	PerfEventsWaits           bool
+	PerfFileEvents            bool
+	PerfFileInstances         bool
+	UserStat                  bool
+	ClientStat                bool
+	TableStat                 bool
+	QueryResponseTime         bool
+	EngineTokudbStatus        bool
+	EngineInnodbStatus        bool
+	AuditLog                  bool
+	SchemaSize                bool
+	PerfApplierByWorker       bool
+	PerfStatusByAccount       bool
+	Hostname                  bool
+	InnodbBufferPoolDump      bool
+	PerfThreadCPU             bool
+	StatusLike                bool
+	RelayLog                  bool
+	InnodbLockTimeouts        bool
+	InnodbFTS                 bool
+	LongTransactions          bool
+	TempTablespaces           bool
+	PerfReplConnStatus        bool
+	VariableDrift             bool
+	PerfWaitsByInstance       bool
+	InnodbPageOps             bool
+	PerfSortTmpByAccount      bool
+	PerfVariablesInfo         bool
+	DDLProgress               bool
+	NetworkStats              bool
+	InnodbLogIO               bool
+	CharsetInventory          bool
+	DurabilitySettings        bool
+	UndoTablespaces           bool
+	Canary                    bool
+	SSLCiphers                bool
+	GTIDGap                   bool
+	DigestSamples             bool
+	ConnectionWatermark       bool
+	TableOpenCache            bool
+	InnodbCheckpoint          bool
+	ThreadCache               bool
+	ReplicationLoop           bool
+	TmpFiles                  bool
+	MysqlX                    bool
+	ConnectionLimitHits       bool
+	ErrorSummary              bool
+	AutoIncrementSummary      bool
+	WarmthState               bool
+	CommitOrderWaits          bool
+	LockErrorsByUser          bool
+	BinlogCompression         bool
+	UnappliedTransactions     bool
+	PreparedStmtCache         bool
+	StatementMix              bool
+	DigestTmpTables           bool
+	QueryRewrite              bool
+	ProtocolCompression       bool
+	TableAccessRatio          bool
+	InnodbFlush               bool
+	ReplicationConfig         bool
+	MyISAMKeyCache            bool
+	IsolationLevels           bool
+	InnodbDoublewrite         bool
+	StatementHistogram        bool
+	Accounts                  bool
+	ServerTime                bool
+	InnodbBufferPoolConfig    bool
+	AppliedTransactions       bool
+	OpenTempTables            bool
+	TimeoutSettings           bool
+	SchemaCacheRisk           bool
+	ApplierIdle               bool
+	InnodbFsyncLatency        bool
+	GTIDIntervals             bool
+	ThreadMemory              bool
+	MemoryLimits              bool
+	ReplicaHealth             bool
+	RoutineStats              bool
+	ListenerBacklog           bool
+	ParallelReplicationConfig bool
+	InnodbStats               bool
+	InnodbTrxMix              bool
+	InnodbAHIBenefit          bool
+	ReplicaDelayConfig        bool
+	PerfSchemaLost            bool
+	OSCInProgress             bool
+	Heartbeat                 bool
+	HeartbeatDatabase         string
+	HeartbeatTable            string
+	CanaryDatabase            string
+	CanaryTable               string
+	CanaryRole                string
+	GTIDPrimaryDSN            string
+	StatusLikePatterns        []string
+	MaxMySQLConns             int
+	// MaxIdleConns bounds the idle connections kept in the pool, 0 keeps one
+	// per enabled collector up to the open connection limit.
+	MaxIdleConns int
+	// ConnMaxLifetime is how long a connection may be reused, 0 means the
+	// default of 2 minutes.
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is how long a connection may sit idle before it is
+	// closed, 0 means no limit. It requires Go 1.15 or later.
+	ConnMaxIdleTime time.Duration
+	// MaxMetricsPerCollector limits the number of metrics a single collector
+	// may emit per scrape, 0 means unlimited.
+	MaxMetricsPerCollector int
+	// StrictCollectors discards the metrics of a collector that returns an
+	// error instead of sending what it emitted before failing.
+	StrictCollectors bool
+	// Database, if set, scopes the schema scanning collectors to one database.
+	Database string
+	// RefreshIntervals, by collector name such as "collect.info_schema.tables",
+	// runs heavy collectors in the background on their own schedule. Scrapes
+	// are served their latest cached metrics. As an exporter is created for
+	// every scrape, a refresh starts on the first scrape of its collector and
+	// keeps running across exporters until Close.
+	RefreshIntervals map[string]time.Duration
 }
 
 // Exporter collects MySQL metrics. It implements prometheus.Collector.
 type Exporter struct {
+	// BeforeScrape, if set, is called at the start of every scrape.
+	BeforeScrape func(ctx context.Context)
+	// AfterScrape, if set, is called once every collector of a scrape has
+	// finished, with the aggregate ScrapeErrors if anything failed.
+	AfterScrape func(ctx context.Context, err error)
+
 	dsn          string
 	collect      Collect
 	error        prometheus.Gauge
@@ -76,8 +222,18 @@ type Exporter struct {
 	mysqldUp     prometheus.Gauge
 }
 
-// New returns a new MySQL exporter for the provided DSN.
+// New returns a new MySQL exporter for the provided DSN. StatusLike replaces
+// GlobalStatus, and either replaces PerfSchemaLost, as they report the same
+// metrics.
 func New(dsn string, collect Collect) *Exporter {
+	if collect.StatusLike && collect.GlobalStatus {
+		log.Infoln("collect.global_status_like is enabled, disabling collect.global_status.")
+		collect.GlobalStatus = false
+	}
+	if collect.PerfSchemaLost && (collect.GlobalStatus || collect.StatusLike) {
+		log.Infoln("collect.global_status or collect.global_status_like is enabled, disabling collect.perf_schema.lost.")
+		collect.PerfSchemaLost = false
+	}
 	return &Exporter{
 		dsn:     dsn,
 		collect: collect,
@@ -107,6 +263,114 @@ func New(dsn string, collect Collect) *Exporter {
 	}
 }
 
+// defaultConnMaxLifetime is how long a connection is reused unless
+// Collect.ConnMaxLifetime says otherwise.
+const defaultConnMaxLifetime = 2 * time.Minute
+
+// connPool is the part of *sql.DB configured by configurePool.
+type connPool interface {
+	SetMaxOpenConns(n int)
+	SetMaxIdleConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+}
+
+// connMaxIdleTimeSetter is implemented by *sql.DB from Go 1.15 on.
+type connMaxIdleTimeSetter interface {
+	SetConnMaxIdleTime(d time.Duration)
+}
+
+// configurePool applies the connection pool settings of collect to pool.
+func configurePool(pool connPool, collect Collect) {
+	maxCon := collect.MaxMySQLConns
+	if maxCon > 16 {
+		maxCon = 16
+	}
+	pool.SetMaxOpenConns(maxCon)
+
+	maxIdle := collect.MaxIdleConns
+	if maxIdle <= 0 {
+		// Collectors run concurrently, keeping a connection for each spares
+		// reconnecting on every scrape.
+		maxIdle = collect.enabledCollectors()
+		if maxCon > 0 && maxIdle > maxCon {
+			maxIdle = maxCon
+		}
+		if maxIdle < 1 {
+			maxIdle = 1
+		}
+	}
+	pool.SetMaxIdleConns(maxIdle)
+
+	lifetime := collect.ConnMaxLifetime
+	if lifetime <= 0 {
+		lifetime = defaultConnMaxLifetime
+	}
+	pool.SetConnMaxLifetime(lifetime)
+
+	if collect.ConnMaxIdleTime > 0 {
+		if setter, ok := pool.(connMaxIdleTimeSetter); ok {
+			setter.SetConnMaxIdleTime(collect.ConnMaxIdleTime)
+		} else {
+			log.Warnln("Connection idle timeout requires Go 1.15 or later, ignoring it.")
+		}
+	}
+}
+
+// enabledCollectors returns the number of collectors enabled in c.
+func (c Collect) enabledCollectors() int {
+	v := reflect.ValueOf(c)
+	n := 0
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Name == "StrictCollectors" {
+			continue
+		}
+		if f := v.Field(i); f.Kind() == reflect.Bool && f.Bool() {
+			n++
+		}
+	}
+	return n
+}
+
+// Close stops the background refreshes of the exporter's collectors, which
+// are shared with every other exporter refreshing the same collectors. The
+// next scrape of such a collector starts its refresh again.
+func (e *Exporter) Close() {
+	for name := range e.collect.RefreshIntervals {
+		stopRefresher(name)
+	}
+}
+
+// ValidateConstLabels checks that labels can be added to the exporter's
+// metrics, e.g. with prometheus.WrapRegistererWith. Names must be valid label
+// names and must not use the reserved "__" prefix. A name clashing with a label
+// of one of the metrics fails the registration of the exporter.
+func ValidateConstLabels(labels prometheus.Labels) error {
+	for name := range labels {
+		if !model.LabelName(name).IsValid() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+			return fmt.Errorf("invalid constant label name %q", name)
+		}
+	}
+	return nil
+}
+
+// CheckDatabase verifies that database exists on the server of the DSN.
+func CheckDatabase(dsn, database string) error {
+	conn, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var count int
+	if err := conn.QueryRow(databaseExistsQuery, database).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("database %q does not exist", database)
+	}
+	return nil
+}
+
 // Describe implements prometheus.Collector.
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	// We cannot know in advance what metrics the exporter will generate
@@ -146,9 +410,25 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 }
 
 func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
+	defer collectorTruncated.Collect(ch)
 	e.totalScrapes.Inc()
+
+	ctx := context.Background()
+	result := &scrapeResult{}
+	if e.BeforeScrape != nil {
+		e.BeforeScrape(ctx)
+	}
+	if e.AfterScrape != nil {
+		defer func() {
+			e.AfterScrape(ctx, result.err())
+		}()
+	}
+	up := false
+	defer func() {
+		recordScrape(up, result)
+	}()
+
 	var err error
-	var wg sync.WaitGroup
 	if atomic.LoadInt32(&inited) == 0 {
 		mtx.Lock()
 		defer mtx.Unlock()
@@ -157,16 +437,11 @@ func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 			if err != nil {
 				log.Errorln("Error opening connection to database:", err)
 				e.error.Set(1)
+				result.add(err)
 				return
 			}
 			atomic.StoreInt32(&inited, 1)
-			maxCon := e.collect.MaxMySQLConns
-			if maxCon > 16 {
-				maxCon = 16
-			}
-			db.SetMaxOpenConns(maxCon)
-			db.SetMaxIdleConns(1)
-			db.SetConnMaxLifetime(2 * time.Minute)
+			configurePool(db, e.collect)
 		}
 	}
 
@@ -175,25 +450,27 @@ func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 		log.Errorln("Error pinging mysqld:", err)
 		e.mysqldUp.Set(0)
 		e.error.Set(1)
+		result.add(err)
 		return
 	}
 
 	isUpRows.Close()
 	e.mysqldUp.Set(1)
-
-	scrapeTime := time.Now()
+	up = true
 
 	if e.collect.SlowLogFilter {
-		wg.Add(1)
+		result.wg.Add(1)
 		go func() {
+			defer result.wg.Done()
+			scrapeTime := time.Now()
 			defer func() {
 				ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "connection")
 			}()
-			defer wg.Done()
 			sessionSettingsRows, err := db.Query(sessionSettingsQuery)
 			if err != nil {
 				log.Errorln("Error setting log_slow_filter:", err)
 				e.error.Set(1)
+				result.add(err)
 				return
 			}
 			sessionSettingsRows.Close()
@@ -201,302 +478,675 @@ func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 	}
 
 	if e.collect.GlobalStatus {
-		wg.Add(1)
-		go func() {
-			wg.Done()
-			scrapeTime = time.Now()
-			if err = ScrapeGlobalStatus(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.global_status:", err)
-				e.scrapeErrors.WithLabelValues("collect.global_status").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.global_status")
-		}()
+		e.scrapeCollector(result, "collect.global_status", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeGlobalStatus(db, ch)
+		})
 	}
 	if e.collect.GlobalVariables {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeGlobalVariables(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.global_variables:", err)
-				e.scrapeErrors.WithLabelValues("collect.global_variables").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.global_variables")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.global_variables", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeGlobalVariables(db, ch)
+		})
 	}
 	if e.collect.SlaveStatus {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeSlaveStatus(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.slave_status:", err)
-				e.scrapeErrors.WithLabelValues("collect.slave_status").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.slave_status")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.slave_status", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeSlaveStatus(db, ch)
+		})
 	}
 	if e.collect.Processlist {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeProcesslist(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.processlist:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.processlist").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.processlist")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.processlist", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeProcesslist(db, ch)
+		})
 	}
 	if e.collect.TableSchema {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeTableSchema(db, ch, &wg); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.tables:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.tables").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.tables")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.tables", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTableSchema(db, ch, e.collect.Database)
+		})
 	}
 	if e.collect.InnodbTablespaces {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeInfoSchemaInnodbTablespaces(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.innodb_sys_tablespaces:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.innodb_sys_tablespaces").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.innodb_sys_tablespaces")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.innodb_sys_tablespaces", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInfoSchemaInnodbTablespaces(db, ch)
+		})
 	}
 	if e.collect.InnodbMetrics {
-		wg.Add(1)
-		go func() {
-			if err = ScrapeInnodbMetrics(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.innodb_metrics:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.innodb_metrics").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.innodb_metrics")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.innodb_metrics", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbMetrics(db, ch)
+		})
 	}
 	if e.collect.AutoIncrementColumns {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeAutoIncrementColumns(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.auto_increment.columns:", err)
-				e.scrapeErrors.WithLabelValues("collect.auto_increment.columns").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.auto_increment.columns")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.auto_increment.columns", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeAutoIncrementColumns(db, ch, e.collect.Database)
+		})
 	}
 	if e.collect.BinlogSize {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeBinlogSize(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.binlog_size:", err)
-				e.scrapeErrors.WithLabelValues("collect.binlog_size").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.binlog_size")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.binlog_size", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeBinlogSize(db, ch)
+		})
 	}
 	if e.collect.PerfTableIOWaits {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfTableIOWaits(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.tableiowaits:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.tableiowaits").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.tableiowaits")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.tableiowaits", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfTableIOWaits(db, ch)
+		})
 	}
 	if e.collect.PerfIndexIOWaits {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfIndexIOWaits(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.indexiowaits:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.indexiowaits").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.indexiowaits")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.indexiowaits", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfIndexIOWaits(db, ch, e.collect.Database)
+		})
 	}
 	if e.collect.PerfTableLockWaits {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfTableLockWaits(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.tablelocks:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.tablelocks").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.tablelocks")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.tablelocks", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfTableLockWaits(db, ch, e.collect.Database)
+		})
 	}
 	if e.collect.PerfEventsStatements {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfEventsStatements(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.eventsstatements:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.eventsstatements").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.eventsstatements")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.eventsstatements", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfEventsStatements(db, ch)
+		})
 	}
 	if e.collect.PerfEventsWaits {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfEventsWaits(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.eventswaits:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.eventswaits").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.eventswaits")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.eventswaits", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfEventsWaits(db, ch)
+		})
 	}
 	if e.collect.PerfFileEvents {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfFileEvents(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.file_events:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.file_events").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.file_events")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.file_events", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfFileEvents(db, ch)
+		})
 	}
 	if e.collect.PerfFileInstances {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapePerfFileInstances(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.perf_schema.file_instances:", err)
-				e.scrapeErrors.WithLabelValues("collect.perf_schema.file_instances").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.perf_schema.file_instances")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.perf_schema.file_instances", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfFileInstances(db, ch)
+		})
 	}
 	if e.collect.UserStat {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeUserStat(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.userstats:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.userstats").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.userstats")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.userstats", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeUserStat(db, ch)
+		})
 	}
 	if e.collect.ClientStat {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeClientStat(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.clientstats:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.clientstats").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.clientstats")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.clientstats", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeClientStat(db, ch)
+		})
 	}
 	if e.collect.TableStat {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeTableStat(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.tablestats:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.tablestats").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.tablestats")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.tablestats", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTableStat(db, ch, e.collect.Database)
+		})
 	}
 	if e.collect.QueryResponseTime {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeQueryResponseTime(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.info_schema.query_response_time:", err)
-				e.scrapeErrors.WithLabelValues("collect.info_schema.query_response_time").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.info_schema.query_response_time")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.info_schema.query_response_time", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeQueryResponseTime(db, ch)
+		})
 	}
 	if e.collect.EngineTokudbStatus {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeEngineTokudbStatus(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.engine_tokudb_status:", err)
-				e.scrapeErrors.WithLabelValues("collect.engine_tokudb_status").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.engine_tokudb_status")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.engine_tokudb_status", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeEngineTokudbStatus(db, ch)
+		})
 	}
 	if e.collect.EngineInnodbStatus {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeEngineInnodbStatus(db, ch); err != nil {
-				log.Errorln("Error scraping for collect.engine_innodb_status:", err)
-				e.scrapeErrors.WithLabelValues("collect.engine_innodb_status").Inc()
-				e.error.Set(1)
-			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.engine_innodb_status")
-			wg.Done()
-		}()
+		e.scrapeCollector(result, "collect.engine_innodb_status", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeEngineInnodbStatus(db, ch)
+		})
+	}
+	if e.collect.AuditLog {
+		e.scrapeCollector(result, "collect.audit_log", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeAuditLog(db, ch)
+		})
+	}
+	if e.collect.SchemaSize {
+		e.scrapeCollector(result, "collect.info_schema.schema_size", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeSchemaSize(db, ch, e.collect.Database)
+		})
+	}
+	if e.collect.PerfApplierByWorker {
+		e.scrapeCollector(result, "collect.perf_schema.replication_applier_status_by_worker", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfReplicationApplierStatsByWorker(db, ch)
+		})
+	}
+	if e.collect.PerfStatusByAccount {
+		e.scrapeCollector(result, "collect.perf_schema.status_by_account", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeStatusByAccount(db, ch)
+		})
+	}
+	if e.collect.Hostname {
+		e.scrapeCollector(result, "collect.hostname", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeHostname(db, ch)
+		})
+	}
+	if e.collect.InnodbBufferPoolDump {
+		e.scrapeCollector(result, "collect.innodb_buffer_pool_dump", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbBufferPoolDump(db, ch)
+		})
+	}
+	if e.collect.PerfThreadCPU {
+		e.scrapeCollector(result, "collect.perf_schema.thread_cpu", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeThreadCPU(db, ch)
+		})
+	}
+	if e.collect.StatusLike {
+		e.scrapeCollector(result, "collect.global_status_like", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeStatusLike(db, ch, e.collect.StatusLikePatterns)
+		})
+	}
+	if e.collect.RelayLog {
+		e.scrapeCollector(result, "collect.relay_log", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeRelayLog(db, ch)
+		})
+	}
+	if e.collect.InnodbLockTimeouts {
+		e.scrapeCollector(result, "collect.engine_innodb_lock_timeouts", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeLockTimeouts(db, ch)
+		})
+	}
+	if e.collect.InnodbFTS {
+		e.scrapeCollector(result, "collect.info_schema.innodb_ft", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbFTS(db, ch)
+		})
+	}
+	if e.collect.LongTransactions {
+		e.scrapeCollector(result, "collect.info_schema.long_transactions", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeLongTransactions(db, ch)
+		})
+	}
+	if e.collect.TempTablespaces {
+		e.scrapeCollector(result, "collect.info_schema.innodb_temp_tablespaces", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTempTablespaces(db, ch)
+		})
+	}
+	if e.collect.PerfReplConnStatus {
+		e.scrapeCollector(result, "collect.perf_schema.replication_connection_status", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfReplicationConnectionStatus(db, ch)
+		})
+	}
+	if e.collect.VariableDrift {
+		e.scrapeCollector(result, "collect.perf_schema.variable_drift", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeVariableDrift(db, ch)
+		})
+	}
+	if e.collect.PerfWaitsByInstance {
+		e.scrapeCollector(result, "collect.perf_schema.waits_by_instance", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfWaitsByInstance(db, ch)
+		})
+	}
+	if e.collect.InnodbPageOps {
+		e.scrapeCollector(result, "collect.info_schema.innodb_page_ops", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbPageOps(db, ch)
+		})
+	}
+	if e.collect.PerfSortTmpByAccount {
+		e.scrapeCollector(result, "collect.perf_schema.sort_tmp_by_account", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeSortTmpByAccount(db, ch)
+		})
+	}
+	if e.collect.PerfVariablesInfo {
+		e.scrapeCollector(result, "collect.perf_schema.variables_info", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeVariablesInfo(db, ch)
+		})
+	}
+	if e.collect.DDLProgress {
+		e.scrapeCollector(result, "collect.perf_schema.ddl_progress", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeDDLProgress(db, ch)
+		})
+	}
+	if e.collect.NetworkStats {
+		e.scrapeCollector(result, "collect.network", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeNetworkStats(db, ch)
+		})
+	}
+	if e.collect.InnodbLogIO {
+		e.scrapeCollector(result, "collect.innodb_log_io", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbLogIO(db, ch)
+		})
+	}
+	if e.collect.CharsetInventory {
+		e.scrapeCollector(result, "collect.info_schema.charset_inventory", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeCharsetInventory(db, ch, e.collect.Database)
+		})
+	}
+	if e.collect.DurabilitySettings {
+		e.scrapeCollector(result, "collect.durability", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeDurabilitySettings(db, ch)
+		})
+	}
+	if e.collect.UndoTablespaces {
+		e.scrapeCollector(result, "collect.info_schema.innodb_undo_tablespaces", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeUndoTablespaces(db, ch)
+		})
+	}
+	if e.collect.Canary {
+		e.scrapeCollector(result, "collect.canary", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeCanary(db, ch, e.collect.CanaryDatabase, e.collect.CanaryTable, e.collect.CanaryRole)
+		})
+	}
+	if e.collect.SSLCiphers {
+		e.scrapeCollector(result, "collect.perf_schema.ssl_ciphers", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeSSLCiphers(db, ch)
+		})
+	}
+	if e.collect.GTIDGap {
+		e.scrapeCollector(result, "collect.slave_gtid_gap", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeGTIDGap(db, ch, e.collect.GTIDPrimaryDSN)
+		})
+	}
+	if e.collect.DigestSamples {
+		e.scrapeCollector(result, "collect.perf_schema.digest_samples", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeDigestSamples(db, ch)
+		})
+	}
+	if e.collect.ConnectionWatermark {
+		e.scrapeCollector(result, "collect.connection_watermark", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeConnectionWatermark(db, ch)
+		})
+	}
+	if e.collect.TableOpenCache {
+		e.scrapeCollector(result, "collect.table_open_cache", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTableOpenCache(db, ch)
+		})
+	}
+	if e.collect.InnodbCheckpoint {
+		e.scrapeCollector(result, "collect.innodb_checkpoint", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbCheckpoint(db, ch)
+		})
+	}
+	if e.collect.ThreadCache {
+		e.scrapeCollector(result, "collect.thread_cache", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeThreadCache(db, ch)
+		})
+	}
+	if e.collect.ReplicationLoop {
+		e.scrapeCollector(result, "collect.slave_loop", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeReplicationLoop(db, ch)
+		})
+	}
+	if e.collect.TmpFiles {
+		e.scrapeCollector(result, "collect.tmp_files", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTmpFiles(db, ch)
+		})
+	}
+	if e.collect.MysqlX {
+		e.scrapeCollector(result, "collect.mysqlx", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeMysqlX(db, ch)
+		})
+	}
+	if e.collect.ConnectionLimitHits {
+		e.scrapeCollector(result, "collect.perf_schema.connection_limit_hits", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeConnectionLimitHits(db, ch)
+		})
+	}
+	if e.collect.ErrorSummary {
+		e.scrapeCollector(result, "collect.perf_schema.error_summary", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeErrorSummary(db, ch)
+		})
+	}
+	if e.collect.AutoIncrementSummary {
+		e.scrapeCollector(result, "collect.auto_increment.summary", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeAutoIncrementSummary(db, ch, e.collect.Database)
+		})
+	}
+	if e.collect.WarmthState {
+		e.scrapeCollector(result, "collect.warmth", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeWarmthState(db, ch)
+		})
+	}
+	if e.collect.CommitOrderWaits {
+		e.scrapeCollector(result, "collect.perf_schema.commit_order_waits", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeCommitOrderWaits(db, ch)
+		})
+	}
+	if e.collect.LockErrorsByUser {
+		e.scrapeCollector(result, "collect.perf_schema.lock_errors_by_user", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeLockErrorsByUser(db, ch)
+		})
+	}
+	if e.collect.BinlogCompression {
+		e.scrapeCollector(result, "collect.binlog_compression", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeBinlogCompression(db, ch)
+		})
+	}
+	if e.collect.UnappliedTransactions {
+		e.scrapeCollector(result, "collect.slave_unapplied_transactions", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeUnappliedTransactions(db, ch)
+		})
+	}
+	if e.collect.PreparedStmtCache {
+		e.scrapeCollector(result, "collect.prepared_stmt", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePreparedStmtCache(db, ch)
+		})
+	}
+	if e.collect.StatementMix {
+		e.scrapeCollector(result, "collect.statement_mix", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeStatementMix(db, ch)
+		})
+	}
+	if e.collect.DigestTmpTables {
+		e.scrapeCollector(result, "collect.perf_schema.digest_tmp_tables", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeDigestTmpTables(db, ch)
+		})
+	}
+	if e.collect.QueryRewrite {
+		e.scrapeCollector(result, "collect.query_rewrite", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeQueryRewrite(db, ch)
+		})
+	}
+	if e.collect.ProtocolCompression {
+		e.scrapeCollector(result, "collect.perf_schema.protocol_compression", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeProtocolCompression(db, ch)
+		})
+	}
+	if e.collect.TableAccessRatio {
+		e.scrapeCollector(result, "collect.perf_schema.table_access_ratio", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTableAccessRatio(db, ch, e.collect.Database)
+		})
+	}
+	if e.collect.InnodbFlush {
+		e.scrapeCollector(result, "collect.innodb_flush", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbFlush(db, ch)
+		})
+	}
+	if e.collect.ReplicationConfig {
+		e.scrapeCollector(result, "collect.slave_source_info", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeReplicationConfig(db, ch)
+		})
+	}
+	if e.collect.MyISAMKeyCache {
+		e.scrapeCollector(result, "collect.myisam_key_cache", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeMyISAMKeyCache(db, ch)
+		})
+	}
+	if e.collect.IsolationLevels {
+		e.scrapeCollector(result, "collect.isolation_levels", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeIsolationLevels(db, ch)
+		})
+	}
+	if e.collect.InnodbDoublewrite {
+		e.scrapeCollector(result, "collect.innodb_doublewrite", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbDoublewrite(db, ch)
+		})
+	}
+	if e.collect.StatementHistogram {
+		e.scrapeCollector(result, "collect.perf_schema.statement_histogram", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeStatementHistogram(db, ch)
+		})
+	}
+	if e.collect.Accounts {
+		e.scrapeCollector(result, "collect.perf_schema.accounts", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeAccounts(db, ch)
+		})
+	}
+	if e.collect.ServerTime {
+		e.scrapeCollector(result, "collect.server_time", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeServerTime(db, ch)
+		})
+	}
+	if e.collect.InnodbBufferPoolConfig {
+		e.scrapeCollector(result, "collect.innodb_buffer_pool_config", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbBufferPoolConfig(db, ch)
+		})
+	}
+	if e.collect.AppliedTransactions {
+		e.scrapeCollector(result, "collect.slave_applied_transactions", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeAppliedTransactions(db, ch)
+		})
+	}
+	if e.collect.OpenTempTables {
+		e.scrapeCollector(result, "collect.tmp_tables_open", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeOpenTempTables(db, ch)
+		})
+	}
+	if e.collect.TimeoutSettings {
+		e.scrapeCollector(result, "collect.timeout_settings", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeTimeoutSettings(db, ch)
+		})
+	}
+	if e.collect.SchemaCacheRisk {
+		e.scrapeCollector(result, "collect.info_schema.table_cache_risk", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeSchemaCacheRisk(db, ch, e.collect.Database)
+		})
+	}
+	if e.collect.ApplierIdle {
+		e.scrapeCollector(result, "collect.slave_applier_idle", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeApplierIdle(db, ch)
+		})
+	}
+	if e.collect.InnodbFsyncLatency {
+		e.scrapeCollector(result, "collect.innodb_fsync_latency", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbFsyncLatency(db, ch)
+		})
+	}
+	if e.collect.GTIDIntervals {
+		e.scrapeCollector(result, "collect.gtid_intervals", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeGTIDIntervals(db, ch)
+		})
+	}
+	if e.collect.ThreadMemory {
+		e.scrapeCollector(result, "collect.perf_schema.thread_memory", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeThreadMemory(db, ch)
+		})
+	}
+	if e.collect.MemoryLimits {
+		e.scrapeCollector(result, "collect.memory_limits", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeMemoryLimits(db, ch)
+		})
+	}
+	if e.collect.ReplicaHealth {
+		e.scrapeCollector(result, "collect.slave_health", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeReplicaHealth(db, ch)
+		})
+	}
+	if e.collect.RoutineStats {
+		e.scrapeCollector(result, "collect.perf_schema.routine_stats", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeRoutineStats(db, ch)
+		})
+	}
+	if e.collect.ListenerBacklog {
+		e.scrapeCollector(result, "collect.listener_backlog", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeListenerBacklog(db, ch, !e.collect.GlobalStatus && !e.collect.StatusLike)
+		})
+	}
+	if e.collect.ParallelReplicationConfig {
+		e.scrapeCollector(result, "collect.slave_parallel_config", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeParallelReplicationConfig(db, ch)
+		})
+	}
+	if e.collect.InnodbStats {
+		e.scrapeCollector(result, "collect.innodb_stats", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbStats(db, ch, e.collect.Database)
+		})
+	}
+	if e.collect.InnodbTrxMix {
+		e.scrapeCollector(result, "collect.innodb_trx_mix", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbTrxMix(db, ch)
+		})
+	}
+	if e.collect.InnodbAHIBenefit {
+		e.scrapeCollector(result, "collect.innodb_ahi_benefit", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeInnodbAHIBenefit(db, ch)
+		})
+	}
+	if e.collect.ReplicaDelayConfig {
+		e.scrapeCollector(result, "collect.slave_delay_config", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeReplicaDelayConfig(db, ch)
+		})
+	}
+	if e.collect.PerfSchemaLost {
+		e.scrapeCollector(result, "collect.perf_schema.lost", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapePerfSchemaLost(db, ch)
+		})
+	}
+	if e.collect.OSCInProgress {
+		e.scrapeCollector(result, "collect.info_schema.online_schema_change", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeOSCInProgress(db, ch, e.collect.Database)
+		})
 	}
 	if e.collect.Heartbeat {
-		wg.Add(1)
-		go func() {
-			scrapeTime = time.Now()
-			if err = ScrapeHeartbeat(db, ch, e.collect.HeartbeatDatabase, e.collect.HeartbeatTable); err != nil {
-				log.Errorln("Error scraping for collect.heartbeat:", err)
-				e.scrapeErrors.WithLabelValues("collect.heartbeat").Inc()
-				e.error.Set(1)
+		e.scrapeCollector(result, "collect.heartbeat", ch, func(ch chan<- prometheus.Metric) error {
+			return ScrapeHeartbeat(db, ch, e.collect.HeartbeatDatabase, e.collect.HeartbeatTable)
+		})
+	}
+	result.wg.Wait()
+
+	enabled, failed := result.collectors()
+	ch <- prometheus.MustNewConstMetric(collectorsEnabledDesc, prometheus.GaugeValue, float64(enabled))
+	ch <- prometheus.MustNewConstMetric(collectorsSucceededDesc, prometheus.GaugeValue, float64(enabled-failed))
+	ch <- prometheus.MustNewConstMetric(collectorsFailedDesc, prometheus.GaugeValue, float64(failed))
+}
+
+// scrapeCollector runs scrape in its own goroutine as part of result,
+// recording its duration and any error it returns under the collector name.
+func (e *Exporter) scrapeCollector(result *scrapeResult, name string, ch chan<- prometheus.Metric, scrape func(chan<- prometheus.Metric) error) {
+	result.start(name)
+	go func() {
+		defer result.wg.Done()
+		scrapeTime := time.Now()
+		if interval := e.collect.RefreshIntervals[name]; interval > 0 {
+			streaming := scrape
+			scrape = func(ch chan<- prometheus.Metric) error {
+				return scrapeRefreshed(name, interval, ch, streaming)
 			}
-			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "collect.heartbeat")
-			wg.Done()
-		}()
+		}
+		if e.collect.StrictCollectors {
+			streaming := scrape
+			scrape = func(ch chan<- prometheus.Metric) error {
+				return scrapeStrict(ch, streaming)
+			}
+		}
+		if err := e.scrapeLimited(name, ch, scrape); err != nil {
+			log.Errorln("Error scraping for "+name+":", err)
+			e.scrapeErrors.WithLabelValues(name).Inc()
+			e.error.Set(1)
+			result.add(err)
+			result.fail(name)
+		}
+		ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), name)
+	}()
+}
+
+// scrapeLimited runs a collector, dropping the metrics it emits beyond
+// MaxMetricsPerCollector.
+func (e *Exporter) scrapeLimited(name string, ch chan<- prometheus.Metric, scrape func(chan<- prometheus.Metric) error) error {
+	max := e.collect.MaxMetricsPerCollector
+	if max <= 0 {
+		return scrape(ch)
+	}
+
+	limitedCh := make(chan prometheus.Metric)
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		count := 0
+		for m := range limitedCh {
+			count++
+			if count <= max {
+				ch <- m
+				continue
+			}
+			// Keep draining so the collector is not blocked.
+			if count == max+1 {
+				collectorTruncated.WithLabelValues(name).Inc()
+				truncatedWarnedMtx.Lock()
+				if !truncatedWarned[name] {
+					truncatedWarned[name] = true
+					log.Warnf("Collector %s emitted more than %d metrics, dropping the rest", name, max)
+				}
+				truncatedWarnedMtx.Unlock()
+			}
+		}
+	}()
+
+	err := scrape(limitedCh)
+	close(limitedCh)
+	<-doneCh
+	return err
+}
+
+// scrapeStrict runs a collector, only sending its metrics on if it succeeds.
+func scrapeStrict(ch chan<- prometheus.Metric, scrape func(chan<- prometheus.Metric) error) error {
+	metrics, err := bufferMetrics(scrape)
+	if err != nil {
+		return err
+	}
+	for _, m := range metrics {
+		ch <- m
+	}
+	return nil
+}
+
+// bufferMetrics runs a collector, returning the metrics it emitted.
+func bufferMetrics(scrape func(chan<- prometheus.Metric) error) ([]prometheus.Metric, error) {
+	bufferCh := make(chan prometheus.Metric)
+	doneCh := make(chan []prometheus.Metric)
+	go func() {
+		var metrics []prometheus.Metric
+		for m := range bufferCh {
+			metrics = append(metrics, m)
+		}
+		doneCh <- metrics
+	}()
+
+	err := scrape(bufferCh)
+	close(bufferCh)
+	return <-doneCh, err
+}
+
+// scrapeResult tracks the collectors run during a single scrape.
+type scrapeResult struct {
+	wg        sync.WaitGroup
+	mtx       sync.Mutex
+	errs      ScrapeErrors
+	enabled   int
+	failed    int
+	succeeded map[string]bool
+}
+
+// start records a collector starting to run.
+func (r *scrapeResult) start(name string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.enabled++
+	if r.succeeded == nil {
+		r.succeeded = map[string]bool{}
+	}
+	r.succeeded[name] = true
+	r.wg.Add(1)
+}
+
+// fail records a collector failing.
+func (r *scrapeResult) fail(name string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.failed++
+	r.succeeded[name] = false
+}
+
+// collectors returns the number of collectors started and failed so far.
+func (r *scrapeResult) collectors() (enabled, failed int) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.enabled, r.failed
+}
+
+func (r *scrapeResult) add(err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+// err returns the errors of the scrape so far, or nil if there were none.
+func (r *scrapeResult) err() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(r.errs) == 0 {
+		return nil
+	}
+	return append(ScrapeErrors(nil), r.errs...)
+}
+
+// ScrapeErrors is the aggregate of the errors that occurred during a scrape.
+type ScrapeErrors []error
+
+func (errs ScrapeErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
 	}
-	wg.Wait()
+	return strings.Join(msgs, "; ")
 }
@@ -1,11 +1,17 @@
 package collector
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
 	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
 const dsn = "root@/mysql"
@@ -45,3 +51,384 @@ func TestExporter(t *testing.T) {
 		}
 	})
 }
+
+func TestExporterScrapeHooks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	// Use the stub database instead of connecting to the DSN.
+	db = mockDB
+	atomic.StoreInt32(&inited, 1)
+	defer func() {
+		db = nil
+		atomic.StoreInt32(&inited, 0)
+	}()
+
+	mock.ExpectQuery(sanitizeQuery(upQuery)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(upQuery)).WillReturnError(errors.New("connection refused"))
+
+	var calls []string
+	var afterErr error
+	exporter := New(dsn, Collect{})
+	exporter.BeforeScrape = func(ctx context.Context) {
+		calls = append(calls, "before")
+	}
+	exporter.AfterScrape = func(ctx context.Context, err error) {
+		calls = append(calls, "after")
+		afterErr = err
+	}
+
+	convey.Convey("Hooks run around a successful scrape", t, func() {
+		exporter.scrape(make(chan prometheus.Metric, 3))
+		convey.So(calls, convey.ShouldResemble, []string{"before", "after"})
+		convey.So(afterErr, convey.ShouldBeNil)
+	})
+
+	calls = nil
+	convey.Convey("AfterScrape receives the scrape error", t, func() {
+		exporter.scrape(make(chan prometheus.Metric))
+		convey.So(calls, convey.ShouldResemble, []string{"before", "after"})
+		convey.So(afterErr, convey.ShouldResemble, ScrapeErrors{errors.New("connection refused")})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestExporterMaxMetricsPerCollector(t *testing.T) {
+	collectorTruncated.Reset()
+	defer collectorTruncated.Reset()
+	defer delete(truncatedWarned, "collect.test")
+
+	exporter := New(dsn, Collect{MaxMetricsPerCollector: 2})
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		result := &scrapeResult{}
+		exporter.scrapeCollector(result, "collect.test", ch, func(ch chan<- prometheus.Metric) error {
+			for i := 0; i < 5; i++ {
+				ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, float64(i), "test")
+			}
+			return nil
+		})
+		result.wg.Wait()
+		close(ch)
+	}()
+
+	convey.Convey("Metrics beyond the limit are dropped", t, func() {
+		var got []MetricResult
+		for m := range ch {
+			got = append(got, readMetric(m))
+		}
+		// Two metrics from the collector plus its duration.
+		convey.So(got, convey.ShouldHaveLength, 3)
+		convey.So(got[0].value, convey.ShouldEqual, 0)
+		convey.So(got[1].value, convey.ShouldEqual, 1)
+	})
+
+	convey.Convey("Truncation is counted", t, func() {
+		m := &dto.Metric{}
+		if err := collectorTruncated.WithLabelValues("collect.test").Write(m); err != nil {
+			t.Fatal(err)
+		}
+		convey.So(m.GetCounter().GetValue(), convey.ShouldEqual, 1)
+		convey.So(truncatedWarned["collect.test"], convey.ShouldBeTrue)
+	})
+}
+
+func TestExporterStrictCollectors(t *testing.T) {
+	failing := func(ch chan<- prometheus.Metric) error {
+		ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, 1, "test")
+		return errors.New("lost connection")
+	}
+
+	for _, strict := range []bool{false, true} {
+		exporter := New(dsn, Collect{StrictCollectors: strict})
+
+		ch := make(chan prometheus.Metric)
+		result := &scrapeResult{}
+		go func() {
+			exporter.scrapeCollector(result, "collect.test", ch, failing)
+			result.wg.Wait()
+			close(ch)
+		}()
+
+		var got []MetricResult
+		for m := range ch {
+			got = append(got, readMetric(m))
+		}
+
+		convey.Convey("Partial results are only sent when not strict", t, func() {
+			if strict {
+				// Only the collector duration.
+				convey.So(got, convey.ShouldHaveLength, 1)
+			} else {
+				convey.So(got, convey.ShouldHaveLength, 2)
+			}
+			convey.So(result.err(), convey.ShouldResemble, ScrapeErrors{errors.New("lost connection")})
+		})
+	}
+}
+
+func TestExporterCollectorCounts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	// Use the stub database instead of connecting to the DSN.
+	db = mockDB
+	atomic.StoreInt32(&inited, 1)
+	defer func() {
+		db = nil
+		atomic.StoreInt32(&inited, 0)
+	}()
+
+	mock.ExpectQuery(sanitizeQuery(upQuery)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(sanitizeQuery(globalStatusQuery)).WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}))
+	mock.ExpectQuery(sanitizeQuery(globalVariablesQuery)).WillReturnError(errors.New("access denied"))
+
+	exporter := New(dsn, Collect{GlobalStatus: true, GlobalVariables: true})
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		exporter.scrape(ch)
+		close(ch)
+	}()
+
+	counts := map[*prometheus.Desc]float64{}
+	for m := range ch {
+		switch m.Desc() {
+		case collectorsEnabledDesc, collectorsSucceededDesc, collectorsFailedDesc:
+			counts[m.Desc()] = readMetric(m).value
+		}
+	}
+
+	convey.Convey("Collector counts match the configured collectors", t, func() {
+		convey.So(counts[collectorsEnabledDesc], convey.ShouldEqual, 2)
+		convey.So(counts[collectorsSucceededDesc], convey.ShouldEqual, 1)
+		convey.So(counts[collectorsFailedDesc], convey.ShouldEqual, 1)
+	})
+
+	convey.Convey("The last scrape status matches the collectors", t, func() {
+		status := LastScrape()
+		convey.So(status.Up, convey.ShouldBeTrue)
+		convey.So(status.Collectors, convey.ShouldResemble, map[string]bool{
+			"collect.global_status":    true,
+			"collect.global_variables": false,
+		})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestExporterConstLabels(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	// Use the stub database instead of connecting to the DSN.
+	db = mockDB
+	atomic.StoreInt32(&inited, 1)
+	defer func() {
+		db = nil
+		atomic.StoreInt32(&inited, 0)
+	}()
+
+	// Registering runs a scrape for Describe, gathering runs another.
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(sanitizeQuery(upQuery)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+		mock.ExpectQuery(sanitizeQuery(globalStatusQuery)).WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("Threads_connected", "3"))
+	}
+
+	// The pedantic registry checks the metrics against their descriptors.
+	registry := prometheus.NewPedanticRegistry()
+	prometheus.WrapRegistererWith(prometheus.Labels{"environment": "prod"}, registry).
+		MustRegister(New(dsn, Collect{GlobalStatus: true}))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("error gathering metrics: %s", err)
+	}
+
+	labels := map[string]labelMap{}
+	for _, family := range families {
+		for _, m := range family.Metric {
+			labels[family.GetName()] = labelMap{}
+			for _, lp := range m.Label {
+				labels[family.GetName()][lp.GetName()] = lp.GetValue()
+			}
+		}
+	}
+
+	convey.Convey("Constant labels are added to exporter and collector metrics", t, func() {
+		convey.So(labels["mysql_up"], convey.ShouldResemble, labelMap{"environment": "prod"})
+		convey.So(labels["mysql_exporter_scrapes_total"], convey.ShouldResemble, labelMap{"environment": "prod"})
+		convey.So(labels["mysql_global_status_threads_connected"], convey.ShouldResemble, labelMap{"environment": "prod"})
+		convey.So(labels["mysql_exporter_collector_duration_seconds"], convey.ShouldResemble, labelMap{"collector": "collect.global_status", "environment": "prod"})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestExporterStatusLikeReplacesGlobalStatus(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	// Use the stub database instead of connecting to the DSN.
+	db = mockDB
+	atomic.StoreInt32(&inited, 1)
+	defer func() {
+		db = nil
+		atomic.StoreInt32(&inited, 0)
+	}()
+
+	// Registering runs a scrape for Describe, gathering runs another. Only
+	// the matching status variables are queried.
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(sanitizeQuery(upQuery)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+		mock.ExpectQuery(sanitizeQuery(globalStatusLikeQuery)).WithArgs("Threads_%").
+			WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Threads_connected", "3"))
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(New(dsn, Collect{
+		GlobalStatus:       true,
+		StatusLike:         true,
+		StatusLikePatterns: []string{"Threads_%"},
+	}))
+	families, err := registry.Gather()
+
+	convey.Convey("Status variables are only reported once", t, func() {
+		convey.So(err, convey.ShouldBeNil)
+		var threadsConnected int
+		for _, family := range families {
+			if family.GetName() == "mysql_global_status_threads_connected" {
+				threadsConnected += len(family.Metric)
+			}
+		}
+		convey.So(threadsConnected, convey.ShouldEqual, 1)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestExporterPerfSchemaLostReplacedByGlobalStatus(t *testing.T) {
+	convey.Convey("perf_schema.lost only runs without the global status collectors", t, func() {
+		convey.So(New(dsn, Collect{PerfSchemaLost: true}).collect.PerfSchemaLost, convey.ShouldBeTrue)
+		convey.So(New(dsn, Collect{PerfSchemaLost: true, GlobalStatus: true}).collect.PerfSchemaLost, convey.ShouldBeFalse)
+		convey.So(New(dsn, Collect{PerfSchemaLost: true, StatusLike: true}).collect.PerfSchemaLost, convey.ShouldBeFalse)
+	})
+}
+
+func TestValidateConstLabels(t *testing.T) {
+	convey.Convey("Constant label names are validated", t, func() {
+		convey.So(ValidateConstLabels(prometheus.Labels{"environment": "prod", "cluster": "payments"}), convey.ShouldBeNil)
+		convey.So(ValidateConstLabels(prometheus.Labels{"1st": "x"}), convey.ShouldNotBeNil)
+		convey.So(ValidateConstLabels(prometheus.Labels{"__name__": "x"}), convey.ShouldNotBeNil)
+	})
+}
+
+func TestExporterConstLabelsCollision(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	// Use the stub database instead of connecting to the DSN.
+	db = mockDB
+	atomic.StoreInt32(&inited, 1)
+	defer func() {
+		db = nil
+		atomic.StoreInt32(&inited, 0)
+	}()
+
+	mock.ExpectQuery(sanitizeQuery(upQuery)).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(globalStatusQuery)).WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("Threads_connected", "3"))
+
+	registry := prometheus.NewPedanticRegistry()
+	err = prometheus.WrapRegistererWith(prometheus.Labels{"collector": "x"}, registry).
+		Register(New(dsn, Collect{GlobalStatus: true}))
+	convey.Convey("Constant labels clashing with a metric label fail registration", t, func() {
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+// recordingPool records the settings configurePool applies.
+type recordingPool struct {
+	maxOpen, maxIdle         int
+	maxLifetime, maxIdleTime time.Duration
+}
+
+func (p *recordingPool) SetMaxOpenConns(n int)              { p.maxOpen = n }
+func (p *recordingPool) SetMaxIdleConns(n int)              { p.maxIdle = n }
+func (p *recordingPool) SetConnMaxLifetime(d time.Duration) { p.maxLifetime = d }
+func (p *recordingPool) SetConnMaxIdleTime(d time.Duration) { p.maxIdleTime = d }
+
+func TestConfigurePool(t *testing.T) {
+	convey.Convey("Configured values are applied", t, func() {
+		pool := &recordingPool{}
+		configurePool(pool, Collect{
+			MaxMySQLConns:   8,
+			MaxIdleConns:    4,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: 30 * time.Second,
+		})
+		convey.So(*pool, convey.ShouldResemble, recordingPool{
+			maxOpen: 8, maxIdle: 4, maxLifetime: 5 * time.Minute, maxIdleTime: 30 * time.Second,
+		})
+	})
+
+	convey.Convey("Defaults scale with the enabled collectors", t, func() {
+		pool := &recordingPool{}
+		configurePool(pool, Collect{
+			MaxMySQLConns:    8,
+			GlobalStatus:     true,
+			GlobalVariables:  true,
+			SlaveStatus:      true,
+			StrictCollectors: true,
+		})
+		convey.So(*pool, convey.ShouldResemble, recordingPool{
+			maxOpen: 8, maxIdle: 3, maxLifetime: defaultConnMaxLifetime,
+		})
+	})
+
+	convey.Convey("Default idle connections are bounded by the open connections", t, func() {
+		pool := &recordingPool{}
+		configurePool(pool, Collect{
+			MaxMySQLConns:   2,
+			GlobalStatus:    true,
+			GlobalVariables: true,
+			SlaveStatus:     true,
+		})
+		convey.So(pool.maxIdle, convey.ShouldEqual, 2)
+	})
+}
@@ -8,15 +8,36 @@ import (
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 const (
 	// Scrape query
 	globalStatusQuery = `SHOW GLOBAL STATUS`
+	// Scrape query from performance_schema, which does not materialize
+	// the session status as well.
+	globalStatusPerfSchemaQuery = `SELECT VARIABLE_NAME, VARIABLE_VALUE FROM performance_schema.global_status`
 	// Subsytem.
 	globalStatus = "global_status"
 )
 
+// Tuning flags.
+var (
+	globalStatusPerfSchema = kingpin.Flag(
+		"collect.global_status.perf_schema",
+		"Read the status from performance_schema.global_status if available instead of SHOW GLOBAL STATUS",
+	).Default("false").Bool()
+	globalStatusInclude = kingpin.Flag(
+		"collect.global_status.include",
+		"Comma separated LIKE patterns of the status variables to collect, all if empty",
+	).Default("").String()
+	globalStatusExclude = kingpin.Flag(
+		"collect.global_status.exclude",
+		"Comma separated LIKE patterns of the status variables not to collect",
+	).Default("").String()
+)
+
 // Regexp to match various groups of status vars.
 var globalStatusRE = regexp.MustCompile(`^(com|handler|connection_errors|innodb_buffer_pool_pages|innodb_rows|performance_schema)_(.*)$`)
 
@@ -58,9 +79,75 @@ var (
 	)
 )
 
+// globalStatusMetric converts a status variable into a metric. Unparsable
+// values yield nil.
+func globalStatusMetric(key string, val sql.RawBytes) prometheus.Metric {
+	floatVal, ok := parseStatus(val)
+	if !ok {
+		return nil
+	}
+	key = strings.ToLower(key)
+	match := globalStatusRE.FindStringSubmatch(key)
+	if match == nil {
+		return prometheus.MustNewConstMetric(
+			newDesc(globalStatus, key, "Generic metric from SHOW GLOBAL STATUS."),
+			prometheus.UntypedValue,
+			floatVal,
+		)
+	}
+	switch match[1] {
+	case "com":
+		return prometheus.MustNewConstMetric(
+			globalCommandsDesc, prometheus.CounterValue, floatVal, match[2],
+		)
+	case "handler":
+		return prometheus.MustNewConstMetric(
+			globalHandlerDesc, prometheus.CounterValue, floatVal, match[2],
+		)
+	case "connection_errors":
+		return prometheus.MustNewConstMetric(
+			globalConnectionErrorsDesc, prometheus.CounterValue, floatVal, match[2],
+		)
+	case "innodb_buffer_pool_pages":
+		switch match[2] {
+		case "data", "dirty", "free", "misc":
+			return prometheus.MustNewConstMetric(
+				globalBufferPoolPagesDesc, prometheus.GaugeValue, floatVal, match[2],
+			)
+		default:
+			return prometheus.MustNewConstMetric(
+				globalBufferPoolPageChangesDesc, prometheus.CounterValue, floatVal, match[2],
+			)
+		}
+	case "innodb_rows":
+		return prometheus.MustNewConstMetric(
+			globalInnoDBRowOpsDesc, prometheus.CounterValue, floatVal, match[2],
+		)
+	case "performance_schema":
+		return prometheus.MustNewConstMetric(
+			globalPerformanceSchemaLostDesc, prometheus.CounterValue, floatVal, match[2],
+		)
+	}
+	return nil
+}
+
 // ScrapeGlobalStatus collects from `SHOW GLOBAL STATUS`.
 func ScrapeGlobalStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
-	globalStatusRows, err := db.Query(globalStatusQuery)
+	query, column := globalStatusQuery, "Variable_name"
+	if *globalStatusPerfSchema {
+		available, err := perfSchemaTableAvailable(db, "global_status")
+		if err != nil {
+			return err
+		}
+		if available {
+			query, column = globalStatusPerfSchemaQuery, "VARIABLE_NAME"
+		} else {
+			log.Debugln("performance_schema.global_status is not available, using SHOW GLOBAL STATUS.")
+		}
+	}
+
+	filter, args := variableNameFilter(column, *globalStatusInclude, *globalStatusExclude)
+	globalStatusRows, err := db.Query(query+filter, args...)
 	if err != nil {
 		return err
 	}
@@ -78,50 +165,8 @@ func ScrapeGlobalStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
 		if err := globalStatusRows.Scan(&key, &val); err != nil {
 			return err
 		}
-		if floatVal, ok := parseStatus(val); ok { // Unparsable values are silently skipped.
-			key = strings.ToLower(key)
-			match := globalStatusRE.FindStringSubmatch(key)
-			if match == nil {
-				ch <- prometheus.MustNewConstMetric(
-					newDesc(globalStatus, key, "Generic metric from SHOW GLOBAL STATUS."),
-					prometheus.UntypedValue,
-					floatVal,
-				)
-				continue
-			}
-			switch match[1] {
-			case "com":
-				ch <- prometheus.MustNewConstMetric(
-					globalCommandsDesc, prometheus.CounterValue, floatVal, match[2],
-				)
-			case "handler":
-				ch <- prometheus.MustNewConstMetric(
-					globalHandlerDesc, prometheus.CounterValue, floatVal, match[2],
-				)
-			case "connection_errors":
-				ch <- prometheus.MustNewConstMetric(
-					globalConnectionErrorsDesc, prometheus.CounterValue, floatVal, match[2],
-				)
-			case "innodb_buffer_pool_pages":
-				switch match[2] {
-				case "data", "dirty", "free", "misc":
-					ch <- prometheus.MustNewConstMetric(
-						globalBufferPoolPagesDesc, prometheus.GaugeValue, floatVal, match[2],
-					)
-				default:
-					ch <- prometheus.MustNewConstMetric(
-						globalBufferPoolPageChangesDesc, prometheus.CounterValue, floatVal, match[2],
-					)
-				}
-			case "innodb_rows":
-				ch <- prometheus.MustNewConstMetric(
-					globalInnoDBRowOpsDesc, prometheus.CounterValue, floatVal, match[2],
-				)
-			case "performance_schema":
-				ch <- prometheus.MustNewConstMetric(
-					globalPerformanceSchemaLostDesc, prometheus.CounterValue, floatVal, match[2],
-				)
-			}
+		if metric := globalStatusMetric(key, val); metric != nil {
+			ch <- metric
 		} else if _, ok := textItems[key]; ok {
 			textItems[key] = string(val)
 		}
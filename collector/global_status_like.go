@@ -0,0 +1,53 @@
+// Scrape `SHOW GLOBAL STATUS LIKE ...`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SHOW statements only accept a literal after LIKE, the pattern is passed as a
+// placeholder through WHERE instead.
+const globalStatusLikeQuery = `SHOW GLOBAL STATUS WHERE Variable_name LIKE ?`
+
+// ScrapeStatusLike collects the status variables matching any of the given
+// `LIKE` patterns. It emits the same metrics as ScrapeGlobalStatus, which New
+// disables when both are enabled.
+func ScrapeStatusLike(db *sql.DB, ch chan<- prometheus.Metric, patterns []string) error {
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		if err := scrapeStatusLikePattern(db, ch, pattern, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scrapeStatusLikePattern(db *sql.DB, ch chan<- prometheus.Metric, pattern string, seen map[string]bool) error {
+	statusRows, err := db.Query(globalStatusLikeQuery, pattern)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var key string
+	var val sql.RawBytes
+
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		// Variables matched by several patterns are only reported once.
+		if seen[strings.ToLower(key)] {
+			continue
+		}
+		seen[strings.ToLower(key)] = true
+		if metric := globalStatusMetric(key, val); metric != nil {
+			ch <- metric
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeStatusLike(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Threads_connected", "10").
+		AddRow("Threads_running", "2")
+	// The pattern is only allowed as a placeholder in the WHERE form.
+	mock.ExpectQuery(regexp.QuoteMeta("SHOW GLOBAL STATUS WHERE Variable_name LIKE ?")).WithArgs("Threads_%").WillReturnRows(rows)
+	rows = sqlmock.NewRows(columns).
+		AddRow("Com_select", "42").
+		AddRow("Threads_running", "2")
+	mock.ExpectQuery(sanitizeQuery(globalStatusLikeQuery)).WithArgs("%running").WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeStatusLike(db, ch, []string{"Threads_%", "%running"}); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 10, metricType: dto.MetricType_UNTYPED},
+		{labels: labelMap{}, value: 2, metricType: dto.MetricType_UNTYPED},
+		{labels: labelMap{"command": "select"}, value: 42, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -71,3 +71,96 @@ func TestScrapeGlobalStatus(t *testing.T) {
 		t.Errorf("there were unfulfilled expections: %s", err)
 	}
 }
+
+func TestScrapeGlobalStatusPerfSchema(t *testing.T) {
+	perfSchema := *globalStatusPerfSchema
+	*globalStatusPerfSchema = true
+	defer func() { *globalStatusPerfSchema = perfSchema }()
+
+	convey.Convey("Status source", t, func() {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+		defer db.Close()
+
+		convey.Convey("From performance_schema when available", func() {
+			mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+				WithArgs("performance_schema", "global_status").
+				WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(globalStatusPerfSchemaQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_NAME", "VARIABLE_VALUE"}).AddRow("Uptime", "10"))
+		})
+		convey.Convey("From SHOW GLOBAL STATUS when performance_schema is off", func() {
+			mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(0))
+			mock.ExpectQuery(sanitizeQuery(globalStatusQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Uptime", "10"))
+		})
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err = ScrapeGlobalStatus(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 10, metricType: dto.MetricType_UNTYPED})
+		for range ch {
+		}
+
+		// Ensure all SQL queries were executed
+		convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+	})
+}
+
+func TestScrapeGlobalStatusFiltered(t *testing.T) {
+	defer func(perfSchema bool, include, exclude string) {
+		*globalStatusPerfSchema, *globalStatusInclude, *globalStatusExclude = perfSchema, include, exclude
+	}(*globalStatusPerfSchema, *globalStatusInclude, *globalStatusExclude)
+	*globalStatusPerfSchema = true
+	*globalStatusInclude = "Innodb_%, Uptime"
+	*globalStatusExclude = "Innodb_buffer_pool_pages_%"
+
+	convey.Convey("The include and exclude lists are applied by the server", t, func() {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+		defer db.Close()
+
+		convey.Convey("In performance_schema", func() {
+			mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+				WithArgs("performance_schema", "global_status").
+				WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(globalStatusPerfSchemaQuery+" WHERE (VARIABLE_NAME LIKE ? OR VARIABLE_NAME LIKE ?) AND VARIABLE_NAME NOT LIKE ?")).
+				WithArgs("Innodb_%", "Uptime", "Innodb_buffer_pool_pages_%").
+				WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_NAME", "VARIABLE_VALUE"}).AddRow("Uptime", "10"))
+		})
+		convey.Convey("In SHOW GLOBAL STATUS", func() {
+			mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(0))
+			mock.ExpectQuery(sanitizeQuery(globalStatusQuery+" WHERE (Variable_name LIKE ? OR Variable_name LIKE ?) AND Variable_name NOT LIKE ?")).
+				WithArgs("Innodb_%", "Uptime", "Innodb_buffer_pool_pages_%").
+				WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Uptime", "10"))
+		})
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err = ScrapeGlobalStatus(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 10, metricType: dto.MetricType_UNTYPED})
+		for range ch {
+		}
+
+		// Ensure all SQL queries were executed
+		convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+	})
+}
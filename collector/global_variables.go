@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 const (
@@ -16,11 +18,43 @@ const (
 	globalVariables = "global_variables"
 	// Metric SQL Queries.
 	globalVariablesQuery = `SHOW GLOBAL VARIABLES`
+	// Metric SQL Queries from performance_schema.
+	globalVariablesPerfSchemaQuery = `SELECT VARIABLE_NAME, VARIABLE_VALUE FROM performance_schema.global_variables`
+)
+
+// Tuning flags.
+var (
+	globalVariablesPerfSchema = kingpin.Flag(
+		"collect.global_variables.perf_schema",
+		"Read the variables from performance_schema.global_variables if available instead of SHOW GLOBAL VARIABLES",
+	).Default("false").Bool()
+	globalVariablesInclude = kingpin.Flag(
+		"collect.global_variables.include",
+		"Comma separated LIKE patterns of the variables to collect, all if empty",
+	).Default("").String()
+	globalVariablesExclude = kingpin.Flag(
+		"collect.global_variables.exclude",
+		"Comma separated LIKE patterns of the variables not to collect",
+	).Default("").String()
 )
 
 // ScrapeGlobalVariables collects from `SHOW GLOBAL VARIABLES`.
 func ScrapeGlobalVariables(db *sql.DB, ch chan<- prometheus.Metric) error {
-	globalVariablesRows, err := db.Query(globalVariablesQuery)
+	query, column := globalVariablesQuery, "Variable_name"
+	if *globalVariablesPerfSchema {
+		available, err := perfSchemaTableAvailable(db, "global_variables")
+		if err != nil {
+			return err
+		}
+		if available {
+			query, column = globalVariablesPerfSchemaQuery, "VARIABLE_NAME"
+		} else {
+			log.Debugln("performance_schema.global_variables is not available, using SHOW GLOBAL VARIABLES.")
+		}
+	}
+
+	filter, args := variableNameFilter(column, *globalVariablesInclude, *globalVariablesExclude)
+	globalVariablesRows, err := db.Query(query+filter, args...)
 	if err != nil {
 		return err
 	}
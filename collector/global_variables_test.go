@@ -80,3 +80,89 @@ func TestParseWsrepProviderOptions(t *testing.T) {
 		convey.So(parseWsrepProviderOptions(testB), convey.ShouldEqual, 131072)
 	})
 }
+
+func TestScrapeGlobalVariablesPerfSchema(t *testing.T) {
+	perfSchema := *globalVariablesPerfSchema
+	*globalVariablesPerfSchema = true
+	defer func() { *globalVariablesPerfSchema = perfSchema }()
+
+	convey.Convey("Variables source", t, func() {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+		defer db.Close()
+
+		convey.Convey("From performance_schema when available", func() {
+			mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+				WithArgs("performance_schema", "global_variables").
+				WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(globalVariablesPerfSchemaQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_NAME", "VARIABLE_VALUE"}).AddRow("wait_timeout", "28800"))
+		})
+		convey.Convey("From SHOW GLOBAL VARIABLES when the table is missing", func() {
+			mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+				WithArgs("performance_schema", "global_variables").
+				WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(0))
+			mock.ExpectQuery(sanitizeQuery(globalVariablesQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("wait_timeout", "28800"))
+		})
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err = ScrapeGlobalVariables(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 28800, metricType: dto.MetricType_GAUGE})
+		for range ch {
+		}
+
+		// Ensure all SQL queries were executed
+		convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+	})
+}
+
+func TestScrapeGlobalVariablesFiltered(t *testing.T) {
+	defer func(perfSchema bool, include, exclude string) {
+		*globalVariablesPerfSchema, *globalVariablesInclude, *globalVariablesExclude = perfSchema, include, exclude
+	}(*globalVariablesPerfSchema, *globalVariablesInclude, *globalVariablesExclude)
+	*globalVariablesPerfSchema = false
+	*globalVariablesInclude = ""
+	*globalVariablesExclude = "performance_schema_%,ssl_%"
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(globalVariablesQuery+" WHERE Variable_name NOT LIKE ? AND Variable_name NOT LIKE ?")).
+		WithArgs("performance_schema_%", "ssl_%").
+		WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("wait_timeout", "28800"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeGlobalVariables(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Excluded variables are filtered by the server", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 28800, metricType: dto.MetricType_GAUGE})
+		for range ch {
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// gtidInterval is an inclusive range of transaction numbers.
+type gtidInterval struct {
+	start, end uint64
+}
+
+// gtidSet holds the sorted, non-overlapping intervals of a GTID set by source
+// UUID, including the tag of tagged GTIDs as uuid:tag.
+type gtidSet map[string][]gtidInterval
+
+// parseGTIDSet parses a GTID set as found in gtid_executed, e.g.
+// "3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5:11-18,\n1c2aad49-...:1-3".
+func parseGTIDSet(s string) (gtidSet, error) {
+	set := gtidSet{}
+	for _, gtids := range strings.Split(s, ",") {
+		gtids = strings.TrimSpace(gtids)
+		if gtids == "" {
+			continue
+		}
+		parts := strings.Split(gtids, ":")
+		source := strings.ToLower(parts[0])
+		for _, part := range parts[1:] {
+			bounds := strings.SplitN(part, "-", 2)
+			start, err := strconv.ParseUint(bounds[0], 10, 64)
+			if err != nil {
+				// Not a number, the tag of the following intervals.
+				source = strings.ToLower(parts[0]) + ":" + part
+				continue
+			}
+			end := start
+			if len(bounds) == 2 {
+				if end, err = strconv.ParseUint(bounds[1], 10, 64); err != nil {
+					return nil, fmt.Errorf("invalid GTID interval %q: %s", part, err)
+				}
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid GTID interval %q", part)
+			}
+			set[source] = append(set[source], gtidInterval{start, end})
+		}
+	}
+	for source, intervals := range set {
+		set[source] = mergeGTIDIntervals(intervals)
+	}
+	return set, nil
+}
+
+// mergeGTIDIntervals sorts intervals and merges the overlapping or adjacent
+// ones.
+func mergeGTIDIntervals(intervals []gtidInterval) []gtidInterval {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+	merged := intervals[:0]
+	for _, interval := range intervals {
+		if n := len(merged); n > 0 && interval.start <= merged[n-1].end+1 {
+			if interval.end > merged[n-1].end {
+				merged[n-1].end = interval.end
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// missing returns, by source, the number of transactions of s that are not
+// in other. Sources without missing transactions are included with 0.
+func (s gtidSet) missing(other gtidSet) map[string]uint64 {
+	counts := make(map[string]uint64, len(s))
+	for source, intervals := range s {
+		var count uint64
+		for _, interval := range intervals {
+			count += interval.end - interval.start + 1
+			for _, o := range other[source] {
+				start, end := interval.start, interval.end
+				if o.start > start {
+					start = o.start
+				}
+				if o.end < end {
+					end = o.end
+				}
+				if start <= end {
+					count -= end - start + 1
+				}
+			}
+		}
+		counts[source] = count
+	}
+	return counts
+}
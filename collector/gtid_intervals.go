@@ -0,0 +1,56 @@
+// Scrape the intervals of the executed GTID set, holes in which can indicate
+// lost transactions.
+
+package collector
+
+import (
+	"database/sql"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric descriptors.
+var (
+	gtidIntervalCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "gtid_interval_count"),
+		"Number of intervals of transactions from the source in gtid_executed, more than 1 when it has holes.",
+		[]string{"source_uuid"}, nil,
+	)
+	gtidHoleTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "gtid_hole_transactions"),
+		"Number of transactions from the source missing between the intervals of gtid_executed.",
+		[]string{"source_uuid"}, nil,
+	)
+)
+
+// ScrapeGTIDIntervals collects, by source UUID, the number of intervals of
+// gtid_executed along with the number of transactions missing between them.
+func ScrapeGTIDIntervals(db *sql.DB, ch chan<- prometheus.Metric) error {
+	executed, err := queryGTIDExecuted(db)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]string, 0, len(executed))
+	for source := range executed {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		intervals := executed[source]
+		var holes uint64
+		for i := 1; i < len(intervals); i++ {
+			holes += intervals[i].start - intervals[i-1].end - 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			gtidIntervalCountDesc, prometheus.GaugeValue, float64(len(intervals)),
+			source,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			gtidHoleTransactionsDesc, prometheus.GaugeValue, float64(holes),
+			source,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeGTIDIntervals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	executed := "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5:11-18:20,\n" +
+		"1c2aad49-a92c-11e5-8f8e-08002717a6a7:1-100"
+	mock.ExpectQuery(sanitizeQuery(gtidExecutedQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@global.gtid_executed"}).AddRow(executed))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeGTIDIntervals(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"source_uuid": "1c2aad49-a92c-11e5-8f8e-08002717a6a7"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"source_uuid": "1c2aad49-a92c-11e5-8f8e-08002717a6a7"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"source_uuid": "3e11fa47-71ca-11e1-9e33-c80aa9429562"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"source_uuid": "3e11fa47-71ca-11e1-9e33-c80aa9429562"}, value: 6, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
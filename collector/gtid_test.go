@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	gtidUUID1 = "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+	gtidUUID2 = "1c2aad49-4d10-11e8-8a26-0242ac110002"
+)
+
+func TestParseGTIDSet(t *testing.T) {
+	convey.Convey("GTID sets", t, func() {
+		convey.Convey("Multiple sources and intervals", func() {
+			set, err := parseGTIDSet("3E11FA47-71CA-11E1-9E33-C80AA9429562:11-18:1-5,\n" + gtidUUID2 + ":7")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(set, convey.ShouldResemble, gtidSet{
+				gtidUUID1: {{1, 5}, {11, 18}},
+				gtidUUID2: {{7, 7}},
+			})
+		})
+		convey.Convey("Overlapping and adjacent intervals are merged", func() {
+			set, err := parseGTIDSet(gtidUUID1 + ":1-5:3-8:9-10:20-25")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(set, convey.ShouldResemble, gtidSet{gtidUUID1: {{1, 10}, {20, 25}}})
+		})
+		convey.Convey("Tagged GTIDs", func() {
+			set, err := parseGTIDSet(gtidUUID1 + ":1-5:batch:1-2")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(set, convey.ShouldResemble, gtidSet{
+				gtidUUID1:            {{1, 5}},
+				gtidUUID1 + ":batch": {{1, 2}},
+			})
+		})
+		convey.Convey("Empty set", func() {
+			set, err := parseGTIDSet("")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(set, convey.ShouldBeEmpty)
+		})
+		convey.Convey("Invalid intervals", func() {
+			_, err := parseGTIDSet(gtidUUID1 + ":5-1")
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = parseGTIDSet(gtidUUID1 + ":1-x")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGTIDSetMissing(t *testing.T) {
+	convey.Convey("Missing transactions", t, func() {
+		primary, _ := parseGTIDSet(gtidUUID1 + ":1-100," + gtidUUID2 + ":1-10")
+
+		convey.Convey("Overlapping intervals", func() {
+			replica, _ := parseGTIDSet(gtidUUID1 + ":1-40:50-90," + gtidUUID2 + ":1-10")
+			convey.So(primary.missing(replica), convey.ShouldResemble, map[string]uint64{gtidUUID1: 19, gtidUUID2: 0})
+		})
+		convey.Convey("Disjoint intervals", func() {
+			replica, _ := parseGTIDSet(gtidUUID1 + ":101-200")
+			convey.So(primary.missing(replica), convey.ShouldResemble, map[string]uint64{gtidUUID1: 100, gtidUUID2: 10})
+		})
+		convey.Convey("Replica ahead", func() {
+			replica, _ := parseGTIDSet(gtidUUID1 + ":1-150," + gtidUUID2 + ":1-10")
+			convey.So(primary.missing(replica), convey.ShouldResemble, map[string]uint64{gtidUUID1: 0, gtidUUID2: 0})
+		})
+	})
+}
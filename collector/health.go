@@ -0,0 +1,48 @@
+package collector
+
+import (
+	"sync"
+	"time"
+)
+
+// ScrapeStatus summarizes the outcome of a scrape.
+type ScrapeStatus struct {
+	// Time is when the scrape finished.
+	Time time.Time `json:"last_scrape"`
+	// Up is whether the MySQL server was reachable.
+	Up bool `json:"mysql_up"`
+	// Collectors holds, by collector name, whether the collector succeeded.
+	Collectors map[string]bool `json:"collectors"`
+}
+
+var (
+	// lastScrape is the status of the latest scrape of any exporter.
+	lastScrape    ScrapeStatus
+	lastScrapeMtx = &sync.Mutex{}
+)
+
+// LastScrape returns the status of the latest scrape, the zero ScrapeStatus
+// if there was none yet.
+func LastScrape() ScrapeStatus {
+	lastScrapeMtx.Lock()
+	defer lastScrapeMtx.Unlock()
+	return lastScrape
+}
+
+// recordScrape records the status of a finished scrape.
+func recordScrape(up bool, result *scrapeResult) {
+	status := ScrapeStatus{
+		Time:       time.Now(),
+		Up:         up,
+		Collectors: map[string]bool{},
+	}
+	result.mtx.Lock()
+	for name, succeeded := range result.succeeded {
+		status.Collectors[name] = succeeded
+	}
+	result.mtx.Unlock()
+
+	lastScrapeMtx.Lock()
+	defer lastScrapeMtx.Unlock()
+	lastScrape = status
+}
@@ -0,0 +1,42 @@
+// Scrape `@@hostname`.
+
+package collector
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const hostnameQuery = `SELECT @@hostname`
+
+// Metric descriptors.
+var hostnameInfoDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "hostname", "info"),
+	"The hostname reported by the MySQL server.",
+	[]string{"hostname"}, nil,
+)
+
+// The hostname rarely changes, so it is looked up once and cached.
+var (
+	hostnameMtx    sync.Mutex
+	cachedHostname string
+)
+
+// ScrapeHostname collects the server's `@@hostname`.
+func ScrapeHostname(db *sql.DB, ch chan<- prometheus.Metric) error {
+	hostnameMtx.Lock()
+	defer hostnameMtx.Unlock()
+
+	if cachedHostname == "" {
+		if err := db.QueryRow(hostnameQuery).Scan(&cachedHostname); err != nil {
+			return err
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		hostnameInfoDesc, prometheus.GaugeValue, 1, cachedHostname,
+	)
+	return nil
+}
@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeHostname(t *testing.T) {
+	cachedHostname = ""
+	defer func() { cachedHostname = "" }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// The hostname is only queried once.
+	mock.ExpectQuery(sanitizeQuery(hostnameQuery)).WillReturnRows(sqlmock.NewRows([]string{"@@hostname"}).AddRow("db-01"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if err = ScrapeHostname(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		expect := MetricResult{labels: labelMap{"hostname": "db-01"}, value: 1, metricType: dto.MetricType_GAUGE}
+		for m := range ch {
+			convey.So(readMetric(m), convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
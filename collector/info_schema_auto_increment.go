@@ -4,23 +4,38 @@ package collector
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const infoSchemaAutoIncrementQuery = `
-		SELECT table_schema, table_name, column_name, auto_increment,
+const (
+	// autoIncrementMaxInt is the largest value of an auto_increment column
+	// given its integer type.
+	autoIncrementMaxInt = `
 		  pow(2, case data_type
 		    when 'tinyint'   then 7
 		    when 'smallint'  then 15
 		    when 'mediumint' then 23
 		    when 'int'       then 31
 		    when 'bigint'    then 63
-		    end+(column_type like '% unsigned'))-1 as max_int
+		    end+(column_type like '%% unsigned'))-1`
+	infoSchemaAutoIncrementQuery = `
+		SELECT table_schema, table_name, column_name, auto_increment,` + autoIncrementMaxInt + ` as max_int
 		  FROM information_schema.tables t
 		  JOIN information_schema.columns c USING (table_schema,table_name)
 		  WHERE c.extra = 'auto_increment' AND t.auto_increment IS NOT NULL
+		  %s
 		`
+	infoSchemaAutoIncrementSummaryQuery = `
+		SELECT table_schema, MAX(auto_increment / (` + autoIncrementMaxInt + `))
+		  FROM information_schema.tables t
+		  JOIN information_schema.columns c USING (table_schema,table_name)
+		  WHERE c.extra = 'auto_increment' AND t.auto_increment IS NOT NULL
+		  %s
+		  GROUP BY table_schema
+		`
+)
 
 var (
 	globalInfoSchemaAutoIncrementDesc = prometheus.NewDesc(
@@ -33,11 +48,18 @@ var (
 		"The max value of an auto_increment column from information_schema.",
 		[]string{"schema", "table", "column"}, nil,
 	)
+	autoIncrementMaxFillRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "auto_increment_max_fill_ratio"),
+		"The highest ratio of current value to max value of the auto_increment columns of a schema.",
+		[]string{"schema"}, nil,
+	)
 )
 
-// ScrapeAutoIncrementColumns collects auto_increment column information.
-func ScrapeAutoIncrementColumns(db *sql.DB, ch chan<- prometheus.Metric) error {
-	autoIncrementRows, err := db.Query(infoSchemaAutoIncrementQuery)
+// ScrapeAutoIncrementColumns collects auto_increment column information, only
+// for the given database if it is not empty.
+func ScrapeAutoIncrementColumns(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := databaseFilter("AND", database)
+	autoIncrementRows, err := db.Query(fmt.Sprintf(infoSchemaAutoIncrementQuery, filter), args...)
 	if err != nil {
 		return err
 	}
@@ -65,3 +87,29 @@ func ScrapeAutoIncrementColumns(db *sql.DB, ch chan<- prometheus.Metric) error {
 	}
 	return nil
 }
+
+// ScrapeAutoIncrementSummary collects, by schema, how close the fullest
+// auto_increment column is to its max value.
+func ScrapeAutoIncrementSummary(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := schemaFilter(database)
+	summaryRows, err := db.Query(fmt.Sprintf(infoSchemaAutoIncrementSummaryQuery, filter), args...)
+	if err != nil {
+		return err
+	}
+	defer summaryRows.Close()
+
+	var (
+		schema string
+		ratio  float64
+	)
+	for summaryRows.Next() {
+		if err := summaryRows.Scan(&schema, &ratio); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			autoIncrementMaxFillRatioDesc, prometheus.GaugeValue, ratio,
+			schema,
+		)
+	}
+	return summaryRows.Err()
+}
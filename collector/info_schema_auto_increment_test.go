@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeAutoIncrementSummary(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "app,shop"
+	defer func() { *tableSchemaDatabases = databases }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"table_schema", "ratio"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", 0.875).
+		AddRow("shop", 0.0001)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(infoSchemaAutoIncrementSummaryQuery, "AND TABLE_SCHEMA IN (?,?)"))).
+		WithArgs("app", "shop").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeAutoIncrementSummary(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "app"}, value: 0.875, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop"}, value: 0.0001, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
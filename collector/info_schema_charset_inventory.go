@@ -0,0 +1,85 @@
+// Scrape table and column counts by character set and collation from
+// `information_schema`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	charsetTablesQuery = `
+		SELECT
+		    ccsa.CHARACTER_SET_NAME,
+		    t.TABLE_COLLATION,
+		    COUNT(*)
+		  FROM information_schema.tables t
+		  JOIN information_schema.collation_character_set_applicability ccsa
+		    ON ccsa.COLLATION_NAME = t.TABLE_COLLATION
+		  WHERE t.TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+		  %s
+		  GROUP BY ccsa.CHARACTER_SET_NAME, t.TABLE_COLLATION
+		`
+	charsetColumnsQuery = `
+		SELECT
+		    CHARACTER_SET_NAME,
+		    COLLATION_NAME,
+		    COUNT(*)
+		  FROM information_schema.columns
+		  WHERE TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+		    AND CHARACTER_SET_NAME IS NOT NULL
+		  %s
+		  GROUP BY CHARACTER_SET_NAME, COLLATION_NAME
+		`
+)
+
+// Metric descriptors.
+var (
+	tableCountByCharsetDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "table_count_by_charset"),
+		"The number of tables using a default character set and collation.",
+		[]string{"charset", "collation"}, nil,
+	)
+	columnCountByCharsetDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "column_count_by_charset"),
+		"The number of string columns using a character set and collation.",
+		[]string{"charset", "collation"}, nil,
+	)
+)
+
+// ScrapeCharsetInventory collects table and column counts by character set
+// and collation from `information_schema`.
+func ScrapeCharsetInventory(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := schemaFilter(database)
+	if err := scrapeCharsetCounts(db, ch, fmt.Sprintf(charsetTablesQuery, filter), args, tableCountByCharsetDesc); err != nil {
+		return err
+	}
+	return scrapeCharsetCounts(db, ch, fmt.Sprintf(charsetColumnsQuery, filter), args, columnCountByCharsetDesc)
+}
+
+func scrapeCharsetCounts(db *sql.DB, ch chan<- prometheus.Metric, query string, args []interface{}, desc *prometheus.Desc) error {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		charset   string
+		collation string
+		count     uint64
+	)
+	for rows.Next() {
+		if err := rows.Scan(&charset, &collation, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			desc, prometheus.GaugeValue, float64(count),
+			charset, collation,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,65 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeCharsetInventory(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "*"
+	defer func() { *tableSchemaDatabases = databases }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"CHARACTER_SET_NAME", "COLLATION_NAME", "COUNT(*)"}
+	tableRows := sqlmock.NewRows(columns).
+		AddRow("latin1", "latin1_swedish_ci", 7).
+		AddRow("utf8", "utf8_general_ci", 2).
+		AddRow("utf8mb4", "utf8mb4_0900_ai_ci", 31)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(charsetTablesQuery, ""))).WillReturnRows(tableRows)
+	columnRows := sqlmock.NewRows(columns).
+		AddRow("latin1", "latin1_swedish_ci", 40).
+		AddRow("utf8mb4", "utf8mb4_bin", 5).
+		AddRow("utf8mb4", "utf8mb4_0900_ai_ci", 120)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(charsetColumnsQuery, ""))).WillReturnRows(columnRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeCharsetInventory(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"charset": "latin1", "collation": "latin1_swedish_ci"}, value: 7, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"charset": "utf8", "collation": "utf8_general_ci"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"charset": "utf8mb4", "collation": "utf8mb4_0900_ai_ci"}, value: 31, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"charset": "latin1", "collation": "latin1_swedish_ci"}, value: 40, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"charset": "utf8mb4", "collation": "utf8mb4_bin"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"charset": "utf8mb4", "collation": "utf8mb4_0900_ai_ci"}, value: 120, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
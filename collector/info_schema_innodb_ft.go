@@ -0,0 +1,98 @@
+// Scrape `information_schema.innodb_ft_*`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// Queries.
+	innodbFTAuxTableQuery = `SELECT @@innodb_ft_aux_table`
+	innodbFTCountsQuery   = `
+		SELECT
+		  (SELECT COUNT(*) FROM information_schema.innodb_ft_deleted),
+		  (SELECT COUNT(*) FROM information_schema.innodb_ft_being_deleted),
+		  (SELECT COUNT(*) FROM information_schema.innodb_ft_index_cache)
+		`
+	innodbFTConfigQuery = `SELECT ` + "`KEY`" + `, VALUE FROM information_schema.innodb_ft_config`
+)
+
+// Metric descriptors.
+var (
+	innodbFTDeletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "ft_deleted_docs"),
+		"Number of documents deleted from the FULLTEXT index but not yet optimized away.",
+		[]string{"table"}, nil,
+	)
+	innodbFTBeingDeletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "ft_being_deleted_docs"),
+		"Number of documents being removed from the FULLTEXT index by OPTIMIZE TABLE.",
+		[]string{"table"}, nil,
+	)
+	innodbFTIndexCacheDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "ft_index_cache_entries"),
+		"Number of entries in the FULLTEXT index cache not yet flushed to disk.",
+		[]string{"table"}, nil,
+	)
+	innodbFTConfigDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "ft_config"),
+		"Numeric FULLTEXT index settings from information_schema.innodb_ft_config.",
+		[]string{"table", "key"}, nil,
+	)
+)
+
+// ScrapeInnodbFTS collects FULLTEXT index stats from `information_schema.innodb_ft_*`.
+// These tables are only populated for the table set in @@innodb_ft_aux_table.
+func ScrapeInnodbFTS(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var auxTable sql.NullString
+	if err := db.QueryRow(innodbFTAuxTableQuery).Scan(&auxTable); err != nil {
+		return err
+	}
+	if auxTable.String == "" {
+		log.Debugln("innodb_ft_aux_table is not set, skipping FULLTEXT index stats.")
+		return nil
+	}
+
+	var deleted, beingDeleted, indexCache uint64
+	if err := db.QueryRow(innodbFTCountsQuery).Scan(&deleted, &beingDeleted, &indexCache); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		innodbFTDeletedDesc, prometheus.GaugeValue, float64(deleted), auxTable.String,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		innodbFTBeingDeletedDesc, prometheus.GaugeValue, float64(beingDeleted), auxTable.String,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		innodbFTIndexCacheDesc, prometheus.GaugeValue, float64(indexCache), auxTable.String,
+	)
+
+	configRows, err := db.Query(innodbFTConfigQuery)
+	if err != nil {
+		return err
+	}
+	defer configRows.Close()
+
+	var (
+		key   string
+		value sql.RawBytes
+	)
+
+	for configRows.Next() {
+		if err := configRows.Scan(&key, &value); err != nil {
+			return err
+		}
+		if floatVal, ok := parseStatus(value); ok { // Non-numeric settings are skipped.
+			ch <- prometheus.MustNewConstMetric(
+				innodbFTConfigDesc, prometheus.UntypedValue, floatVal,
+				auxTable.String, strings.ToLower(key),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbFTS(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbFTAuxTableQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@innodb_ft_aux_table"}).AddRow("test/articles"))
+	mock.ExpectQuery(sanitizeQuery(innodbFTCountsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"deleted", "being_deleted", "index_cache"}).AddRow(12, 3, 240))
+
+	columns := []string{"KEY", "VALUE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("optimize_checkpoint_limit", "180").
+		AddRow("synced_doc_id", "1234").
+		AddRow("stopword_table_name", "").
+		AddRow("use_stopword", "1")
+	mock.ExpectQuery(sanitizeQuery(innodbFTConfigQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbFTS(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricsExpected := []MetricResult{
+		{labels: labelMap{"table": "test/articles"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"table": "test/articles"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"table": "test/articles"}, value: 240, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"table": "test/articles", "key": "optimize_checkpoint_limit"}, value: 180, metricType: dto.MetricType_UNTYPED},
+		{labels: labelMap{"table": "test/articles", "key": "synced_doc_id"}, value: 1234, metricType: dto.MetricType_UNTYPED},
+		{labels: labelMap{"table": "test/articles", "key": "use_stopword"}, value: 1, metricType: dto.MetricType_UNTYPED},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricsExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeInnodbFTSNoAuxTable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbFTAuxTableQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@innodb_ft_aux_table"}).AddRow(nil))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbFTS(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without an aux table", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Scrape InnoDB index page splits and merges from `information_schema.innodb_metrics`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const infoSchemaInnodbPageOpsQuery = `
+		SELECT name, count
+		  FROM information_schema.innodb_metrics
+		  WHERE name IN (
+		    'index_page_splits', 'index_page_discards',
+		    'index_page_merge_attempts', 'index_page_merge_successful',
+		    'index_page_reorg_attempts', 'index_page_reorg_successful'
+		  )
+		`
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbPageOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "index_page_ops_total"),
+		"Total number of InnoDB index page operations, requires the innodb_metrics index module to be enabled.",
+		[]string{"operation"}, nil,
+	)
+	infoSchemaInnodbPageMergeRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "index_page_merge_success_ratio"),
+		"Ratio of successful to attempted InnoDB index page merges.",
+		nil, nil,
+	)
+	infoSchemaInnodbPageReorgRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "index_page_reorg_success_ratio"),
+		"Ratio of successful to attempted InnoDB index page reorganizations.",
+		nil, nil,
+	)
+)
+
+// ScrapeInnodbPageOps collects InnoDB index page splits, merges and reorganizations.
+func ScrapeInnodbPageOps(db *sql.DB, ch chan<- prometheus.Metric) error {
+	pageOpsRows, err := db.Query(infoSchemaInnodbPageOpsQuery)
+	if err != nil {
+		return err
+	}
+	defer pageOpsRows.Close()
+
+	var (
+		name  string
+		value float64
+	)
+	values := map[string]float64{}
+
+	for pageOpsRows.Next() {
+		if err := pageOpsRows.Scan(&name, &value); err != nil {
+			return err
+		}
+		values[name] = value
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbPageOpsDesc, prometheus.CounterValue, value,
+			strings.TrimPrefix(name, "index_page_"),
+		)
+	}
+
+	// Ratios are only meaningful once something was attempted.
+	if attempts := values["index_page_merge_attempts"]; attempts > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbPageMergeRatioDesc, prometheus.GaugeValue, values["index_page_merge_successful"]/attempts,
+		)
+	}
+	if attempts := values["index_page_reorg_attempts"]; attempts > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbPageReorgRatioDesc, prometheus.GaugeValue, values["index_page_reorg_successful"]/attempts,
+		)
+	}
+	return nil
+}
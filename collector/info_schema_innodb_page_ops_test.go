@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbPageOps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"name", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("index_page_splits", "1200").
+		AddRow("index_page_merge_attempts", "400").
+		AddRow("index_page_merge_successful", "100").
+		AddRow("index_page_reorg_attempts", "0").
+		AddRow("index_page_reorg_successful", "0").
+		AddRow("index_page_discards", "3")
+	mock.ExpectQuery(sanitizeQuery(infoSchemaInnodbPageOpsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbPageOps(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"operation": "splits"}, value: 1200, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"operation": "merge_attempts"}, value: 400, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"operation": "merge_successful"}, value: 100, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"operation": "reorg_attempts"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"operation": "reorg_successful"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"operation": "discards"}, value: 3, metricType: dto.MetricType_COUNTER},
+		// No reorg ratio without attempts.
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Scrape `information_schema.innodb_session_temp_tablespaces`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const innodbTempTablespacesQuery = `
+	SELECT STATE, COUNT(*), COALESCE(SUM(SIZE), 0)
+	  FROM information_schema.innodb_session_temp_tablespaces
+	  GROUP BY STATE
+	`
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbTempTablespacesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_session_temp_tablespaces"),
+		"The number of InnoDB session temporary tablespaces by state.",
+		[]string{"state"}, nil,
+	)
+	infoSchemaInnodbTempTablespacesSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_session_temp_tablespaces_size_bytes"),
+		"The total size allocated to InnoDB session temporary tablespaces.",
+		nil, nil,
+	)
+)
+
+// ScrapeTempTablespaces collects from `information_schema.innodb_session_temp_tablespaces`.
+func ScrapeTempTablespaces(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := tableExists(db, "information_schema", "INNODB_SESSION_TEMP_TABLESPACES")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("information_schema.innodb_session_temp_tablespaces is not available.")
+		return nil
+	}
+
+	tempTablespacesRows, err := db.Query(innodbTempTablespacesQuery)
+	if err != nil {
+		return err
+	}
+	defer tempTablespacesRows.Close()
+
+	var (
+		state       string
+		count, size uint64
+		totalSize   uint64
+	)
+
+	for tempTablespacesRows.Next() {
+		if err := tempTablespacesRows.Scan(&state, &count, &size); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbTempTablespacesDesc, prometheus.GaugeValue, float64(count),
+			strings.ToLower(state),
+		)
+		totalSize += size
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		infoSchemaInnodbTempTablespacesSizeDesc, prometheus.GaugeValue, float64(totalSize),
+	)
+	return nil
+}
@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeTempTablespaces(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("information_schema", "INNODB_SESSION_TEMP_TABLESPACES").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	columns := []string{"STATE", "COUNT(*)", "SUM(SIZE)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("ACTIVE", "2", "5242880").
+		AddRow("INACTIVE", "8", "655360")
+	mock.ExpectQuery(sanitizeQuery(innodbTempTablespacesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTempTablespaces(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricsExpected := []MetricResult{
+		{labels: labelMap{"state": "active"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "inactive"}, value: 8, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 5898240, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricsExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeTempTablespacesUnavailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("information_schema", "INNODB_SESSION_TEMP_TABLESPACES").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTempTablespaces(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without the table", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Scrape InnoDB undo tablespace truncation from `information_schema.innodb_metrics`
+// and undo tablespace sizes from `information_schema.innodb_tablespaces`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	infoSchemaUndoTruncateQuery = `
+		SELECT name, count
+		  FROM information_schema.innodb_metrics
+		  WHERE name IN (
+		    'undo_truncate_count', 'undo_truncate_start_logging_count',
+		    'undo_truncate_done_logging_count', 'undo_truncate_usec'
+		  )
+		`
+	infoSchemaUndoTablespacesQuery = `
+		SELECT NAME, COALESCE(STATE, ''), FILE_SIZE, ALLOCATED_SIZE
+		  FROM information_schema.innodb_tablespaces
+		  WHERE SPACE_TYPE = 'Undo'
+		`
+)
+
+// Metric descriptors.
+var (
+	infoSchemaUndoTruncationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "undo_truncations_total"),
+		"Total number of InnoDB undo tablespace truncations, requires the innodb_metrics purge module to be enabled.",
+		nil, nil,
+	)
+	infoSchemaUndoTruncateLoggingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "undo_truncate_logging_total"),
+		"Total number of InnoDB undo truncate log files created and removed.",
+		[]string{"event"}, nil,
+	)
+	infoSchemaUndoTruncateSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "undo_truncate_seconds_total"),
+		"Total time spent truncating InnoDB undo tablespaces.",
+		nil, nil,
+	)
+	infoSchemaUndoTablespaceSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "undo_tablespace_size_bytes"),
+		"The apparent and allocated size of an InnoDB undo tablespace.",
+		[]string{"tablespace", "state", "type"}, nil,
+	)
+)
+
+// ScrapeUndoTablespaces collects InnoDB undo tablespace truncation activity
+// and sizes. Servers lacking undo tablespaces in
+// `information_schema.innodb_tablespaces` (before MySQL 8.0) are skipped.
+func ScrapeUndoTablespaces(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := columnExists(db, "information_schema", "INNODB_TABLESPACES", "SPACE_TYPE")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("information_schema.innodb_tablespaces undo tablespaces are not available.")
+		return nil
+	}
+
+	truncateRows, err := db.Query(infoSchemaUndoTruncateQuery)
+	if err != nil {
+		return err
+	}
+	defer truncateRows.Close()
+
+	var (
+		name  string
+		value float64
+	)
+	for truncateRows.Next() {
+		if err := truncateRows.Scan(&name, &value); err != nil {
+			return err
+		}
+		switch name {
+		case "undo_truncate_count":
+			ch <- prometheus.MustNewConstMetric(infoSchemaUndoTruncationsDesc, prometheus.CounterValue, value)
+		case "undo_truncate_start_logging_count":
+			ch <- prometheus.MustNewConstMetric(infoSchemaUndoTruncateLoggingDesc, prometheus.CounterValue, value, "start")
+		case "undo_truncate_done_logging_count":
+			ch <- prometheus.MustNewConstMetric(infoSchemaUndoTruncateLoggingDesc, prometheus.CounterValue, value, "done")
+		case "undo_truncate_usec":
+			ch <- prometheus.MustNewConstMetric(infoSchemaUndoTruncateSecondsDesc, prometheus.CounterValue, value/1e6)
+		}
+	}
+	if err := truncateRows.Err(); err != nil {
+		return err
+	}
+
+	tablespacesRows, err := db.Query(infoSchemaUndoTablespacesQuery)
+	if err != nil {
+		return err
+	}
+	defer tablespacesRows.Close()
+
+	var (
+		tablespace, state       string
+		fileSize, allocatedSize uint64
+	)
+	for tablespacesRows.Next() {
+		if err := tablespacesRows.Scan(&tablespace, &state, &fileSize, &allocatedSize); err != nil {
+			return err
+		}
+		state = strings.ToLower(state)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaUndoTablespaceSizeDesc, prometheus.GaugeValue, float64(fileSize),
+			tablespace, state, "file_size",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaUndoTablespaceSizeDesc, prometheus.GaugeValue, float64(allocatedSize),
+			tablespace, state, "allocated_size",
+		)
+	}
+	return tablespacesRows.Err()
+}
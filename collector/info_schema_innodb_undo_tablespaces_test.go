@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeUndoTablespaces(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(columnExistsQuery)).
+		WithArgs("information_schema", "INNODB_TABLESPACES", "SPACE_TYPE").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"name", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("undo_truncate_count", "4").
+		AddRow("undo_truncate_start_logging_count", "4").
+		AddRow("undo_truncate_done_logging_count", "3").
+		AddRow("undo_truncate_usec", "2500000")
+	mock.ExpectQuery(sanitizeQuery(infoSchemaUndoTruncateQuery)).WillReturnRows(rows)
+
+	columns = []string{"NAME", "STATE", "FILE_SIZE", "ALLOCATED_SIZE"}
+	rows = sqlmock.NewRows(columns).
+		AddRow("innodb_undo_001", "active", "16777216", "16777216").
+		AddRow("innodb_undo_002", "empty", "33554432", "2097152")
+	mock.ExpectQuery(sanitizeQuery(infoSchemaUndoTablespacesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeUndoTablespaces(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 4, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event": "start"}, value: 4, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event": "done"}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 2.5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"tablespace": "innodb_undo_001", "state": "active", "type": "file_size"}, value: 16777216, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace": "innodb_undo_001", "state": "active", "type": "allocated_size"}, value: 16777216, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace": "innodb_undo_002", "state": "empty", "type": "file_size"}, value: 33554432, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace": "innodb_undo_002", "state": "empty", "type": "allocated_size"}, value: 2097152, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeUndoTablespacesUnavailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(columnExistsQuery)).
+		WithArgs("information_schema", "INNODB_TABLESPACES", "SPACE_TYPE").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeUndoTablespaces(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without undo tablespaces", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
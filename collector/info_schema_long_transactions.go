@@ -0,0 +1,85 @@
+// Scrape long running transactions from `information_schema.innodb_trx`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const longTransactionsQuery = `
+		SELECT trx.trx_id, COALESCE(p.user, ''), COALESCE(p.host, ''), COALESCE(trx.trx_query, ''),
+		       UNIX_TIMESTAMP(NOW()) - UNIX_TIMESTAMP(trx.trx_started)
+		  FROM information_schema.innodb_trx trx
+		  JOIN information_schema.processlist p ON p.id = trx.trx_mysql_thread_id
+		  WHERE trx.trx_started <= NOW() - INTERVAL %d SECOND
+		  ORDER BY trx.trx_started
+		  LIMIT %d
+		`
+
+// Maximum length of the query label, in characters.
+const longTransactionsQueryMaxLength = 100
+
+var (
+	// Tunable flags.
+	longTransactionsMinTime = kingpin.Flag(
+		"collect.info_schema.long_transactions.min_time",
+		"Minimum age in seconds of a transaction to be reported",
+	).Default("60").Int()
+	longTransactionsLimit = kingpin.Flag(
+		"collect.info_schema.long_transactions.limit",
+		"Maximum number of transactions to report, oldest first",
+	).Default("10").Int()
+	// Prometheus descriptors.
+	longTransactionAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "long_transaction_age_seconds"),
+		"The age of the oldest running InnoDB transactions.",
+		[]string{"trx_id", "user", "host", "query"}, nil)
+)
+
+// truncateQuery collapses whitespace in a query and cuts it to at most max
+// characters without splitting a multi-byte character.
+func truncateQuery(query string, max int) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if utf8.RuneCountInString(query) <= max {
+		return query
+	}
+	runes := 0
+	for i := range query {
+		if runes == max {
+			return query[:i] + "..."
+		}
+		runes++
+	}
+	return query
+}
+
+// ScrapeLongTransactions collects the oldest running InnoDB transactions.
+func ScrapeLongTransactions(db *sql.DB, ch chan<- prometheus.Metric) error {
+	longTransactionsRows, err := db.Query(fmt.Sprintf(longTransactionsQuery, *longTransactionsMinTime, *longTransactionsLimit))
+	if err != nil {
+		return err
+	}
+	defer longTransactionsRows.Close()
+
+	var (
+		trxID, user, host, query string
+		age                      float64
+	)
+
+	for longTransactionsRows.Next() {
+		if err := longTransactionsRows.Scan(&trxID, &user, &host, &query, &age); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			longTransactionAgeDesc, prometheus.GaugeValue, age,
+			trxID, user, host, truncateQuery(query, longTransactionsQueryMaxLength),
+		)
+	}
+	return nil
+}
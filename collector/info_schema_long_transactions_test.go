@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeLongTransactions(t *testing.T) {
+	minTime, limit := *longTransactionsMinTime, *longTransactionsLimit
+	*longTransactionsMinTime, *longTransactionsLimit = 60, 2
+	defer func() { *longTransactionsMinTime, *longTransactionsLimit = minTime, limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"trx_id", "user", "host", "trx_query", "age"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("4868", "app", "10.0.0.1:5123", "UPDATE t\n   SET a = 1\n WHERE id = 1", "3600").
+		AddRow("4870", "report", "10.0.0.2:6001", "", "75")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(longTransactionsQuery, 60, 2))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeLongTransactions(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricsExpected := []MetricResult{
+		{labels: labelMap{"trx_id": "4868", "user": "app", "host": "10.0.0.1:5123", "query": "UPDATE t SET a = 1 WHERE id = 1"}, value: 3600, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"trx_id": "4870", "user": "report", "host": "10.0.0.2:6001", "query": ""}, value: 75, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricsExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestTruncateQuery(t *testing.T) {
+	convey.Convey("Queries are truncated on character boundaries", t, func() {
+		convey.So(truncateQuery("SELECT  1", 10), convey.ShouldEqual, "SELECT 1")
+		convey.So(truncateQuery(strings.Repeat("a", 12), 10), convey.ShouldEqual, "aaaaaaaaaa...")
+		convey.So(truncateQuery("SELECT 'ééé'", 10), convey.ShouldEqual, "SELECT 'éé...")
+	})
+}
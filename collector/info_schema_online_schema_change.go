@@ -0,0 +1,64 @@
+// Scrape the shadow tables of online schema change tools from
+// `information_schema.tables`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const onlineSchemaChangeTablesQuery = `
+		SELECT TABLE_SCHEMA, TABLE_NAME
+		  FROM information_schema.tables
+		  WHERE TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+		    AND (%s)
+		  %s
+		  ORDER BY TABLE_SCHEMA, TABLE_NAME
+		`
+
+// Tunable flags.
+var onlineSchemaChangePatterns = kingpin.Flag(
+	"collect.info_schema.online_schema_change.patterns",
+	"Comma separated LIKE patterns of the table names online schema change tools create",
+).Default(`\_%\_gho,\_%\_ghc,\_%\_new,%\_osc%`).String()
+
+// Metric descriptors.
+var (
+	onlineSchemaChangeInProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "online_schema_change_in_progress"),
+		"Whether a table created by an online schema change tool exists, labeled with the name of that table.",
+		[]string{"schema", "table"}, nil,
+	)
+)
+
+// ScrapeOSCInProgress collects the tables matching the table names created by
+// online schema change tools, by default the gh-ost ghost and changelog tables
+// and the pt-online-schema-change new tables. A table left behind by an
+// aborted migration is reported as well until it is dropped.
+func ScrapeOSCInProgress(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	_, patternArgs := listArgs(*onlineSchemaChangePatterns)
+	patterns := strings.TrimSuffix(strings.Repeat("TABLE_NAME LIKE ? OR ", len(patternArgs)), " OR ")
+	filter, filterArgs := schemaFilter(database)
+	oscRows, err := db.Query(fmt.Sprintf(onlineSchemaChangeTablesQuery, patterns, filter), append(patternArgs, filterArgs...)...)
+	if err != nil {
+		return err
+	}
+	defer oscRows.Close()
+
+	var schema, table string
+	for oscRows.Next() {
+		if err := oscRows.Scan(&schema, &table); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			onlineSchemaChangeInProgressDesc, prometheus.GaugeValue, 1,
+			schema, table,
+		)
+	}
+	return oscRows.Err()
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeOSCInProgress(t *testing.T) {
+	patterns := *onlineSchemaChangePatterns
+	*onlineSchemaChangePatterns = `\_%\_gho, \_%\_ghc`
+	defer func() { *onlineSchemaChangePatterns = patterns }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"TABLE_SCHEMA", "TABLE_NAME"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "_orders_ghc").
+		AddRow("app", "_orders_gho")
+	query := fmt.Sprintf(onlineSchemaChangeTablesQuery, "TABLE_NAME LIKE ? OR TABLE_NAME LIKE ?", "AND TABLE_SCHEMA = ?")
+	mock.ExpectQuery(sanitizeQuery(query)).
+		WithArgs(`\_%\_gho`, `\_%\_ghc`, "app").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeOSCInProgress(db, ch, "app"); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "app", "table": "_orders_ghc"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "table": "_orders_gho"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -5,6 +5,7 @@ package collector
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,12 +21,65 @@ const infoSchemaProcesslistQuery = `
 		  ORDER BY null
 		`
 
+// infoSchemaProcesslistStateTimeQuery counts the threads by state up to each
+// of processlistStateTimeBuckets, in seconds.
+const infoSchemaProcesslistStateTimeQuery = `
+		SELECT COALESCE(command,''),COALESCE(state,''),
+		    SUM(time <= 1),SUM(time <= 10),SUM(time <= 60),count(*)
+		  FROM information_schema.processlist
+		  WHERE ID != connection_id()
+		    AND TIME >= %d
+		  GROUP BY command,state
+		  ORDER BY null
+		`
+
+// infoSchemaProcesslistByDBQuery counts the connections by default database,
+// connections without a database selected are counted under an empty db.
+const infoSchemaProcesslistByDBQuery = `
+		SELECT COALESCE(db,''),count(*)
+		  FROM information_schema.processlist
+		  WHERE ID != connection_id()
+		  GROUP BY db
+		  ORDER BY count(*) DESC
+		  LIMIT %d
+		`
+
+// infoSchemaProcesslistByCommandQuery counts the connections by command, the
+// commands being a fixed set of the server.
+const infoSchemaProcesslistByCommandQuery = `
+		SELECT COALESCE(command,''),count(*)
+		  FROM information_schema.processlist
+		  WHERE ID != connection_id()
+		  GROUP BY command
+		  ORDER BY command
+		`
+
+// processlistStateTimeBuckets are the upper bounds of the time in state
+// buckets, matching the columns of infoSchemaProcesslistStateTimeQuery.
+var processlistStateTimeBuckets = []string{"1", "10", "60", "+Inf"}
+
 var (
 	// Tunable flags.
 	processlistMinTime = kingpin.Flag(
 		"collect.info_schema.processlist.min_time",
 		"Minimum time a thread must be in each state to be counted",
 	).Default("0").Int()
+	processlistStateTime = kingpin.Flag(
+		"collect.info_schema.processlist.state_time",
+		"Collect the number of threads in each state bucketed by the time spent in that state",
+	).Default("false").Bool()
+	processlistByDB = kingpin.Flag(
+		"collect.info_schema.processlist.by_db",
+		"Collect the number of connections by default database",
+	).Default("false").Bool()
+	processlistByDBLimit = kingpin.Flag(
+		"collect.info_schema.processlist.by_db.limit",
+		"Maximum number of databases to collect the connections of, ordered by number of connections",
+	).Default("100").Int()
+	processlistByCommand = kingpin.Flag(
+		"collect.info_schema.processlist.by_command",
+		"Collect the number of connections by command",
+	).Default("false").Bool()
 	// Prometheus descriptors.
 	processlistCountDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, informationSchema, "threads"),
@@ -35,6 +89,18 @@ var (
 		prometheus.BuildFQName(namespace, informationSchema, "threads_seconds"),
 		"The number of seconds threads (connections) have used split by current state.",
 		[]string{"state"}, nil)
+	processlistStateTimeBucketDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "processlist_state_time_bucket"),
+		"The cumulative number of threads (connections) split by current state that have been in that state for at most le seconds.",
+		[]string{"state", "le"}, nil)
+	processlistConnectionsByDBDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "processlist_connections_by_db"),
+		"The number of connections (threads) split by default database, empty if none is selected.",
+		[]string{"db"}, nil)
+	processlistConnectionsByCommandDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "processlist_connections_by_command"),
+		"The number of connections (threads) split by command, e.g. Binlog Dump for each connected replica.",
+		[]string{"command"}, nil)
 )
 
 // whitelist for connection/process states in SHOW PROCESSLIST
@@ -189,5 +255,114 @@ func ScrapeProcesslist(db *sql.DB, ch chan<- prometheus.Metric) error {
 		ch <- prometheus.MustNewConstMetric(processlistTimeDesc, prometheus.GaugeValue, float64(time), state)
 	}
 
+	if *processlistStateTime {
+		if err := scrapeProcesslistStateTime(db, ch); err != nil {
+			return err
+		}
+	}
+	if *processlistByDB {
+		if err := scrapeProcesslistByDB(db, ch); err != nil {
+			return err
+		}
+	}
+	if *processlistByCommand {
+		return scrapeProcesslistByCommand(db, ch)
+	}
 	return nil
 }
+
+// scrapeProcesslistStateTime collects the number of threads by state bucketed
+// by the time spent in that state. Sleeping connections are bucketed
+// separately as the idle state.
+func scrapeProcesslistStateTime(db *sql.DB, ch chan<- prometheus.Metric) error {
+	stateTimeRows, err := db.Query(fmt.Sprintf(infoSchemaProcesslistStateTimeQuery, *processlistMinTime))
+	if err != nil {
+		return err
+	}
+	defer stateTimeRows.Close()
+
+	var (
+		command, state string
+		counts         = make([]uint32, len(processlistStateTimeBuckets))
+		scanArgs       = []interface{}{&command, &state}
+	)
+	for i := range counts {
+		scanArgs = append(scanArgs, &counts[i])
+	}
+	stateBuckets := map[string][]uint32{}
+	for stateTimeRows.Next() {
+		if err := stateTimeRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+		realState := deriveThreadState(command, state)
+		buckets, ok := stateBuckets[realState]
+		if !ok {
+			buckets = make([]uint32, len(processlistStateTimeBuckets))
+			stateBuckets[realState] = buckets
+		}
+		for i, count := range counts {
+			buckets[i] += count
+		}
+	}
+	if err := stateTimeRows.Err(); err != nil {
+		return err
+	}
+
+	states := make([]string, 0, len(stateBuckets))
+	for state := range stateBuckets {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	for _, state := range states {
+		for i, le := range processlistStateTimeBuckets {
+			ch <- prometheus.MustNewConstMetric(
+				processlistStateTimeBucketDesc, prometheus.GaugeValue, float64(stateBuckets[state][i]),
+				state, le,
+			)
+		}
+	}
+	return nil
+}
+
+// scrapeProcesslistByDB collects the number of connections by default
+// database, limited to the databases with the most connections.
+func scrapeProcesslistByDB(db *sql.DB, ch chan<- prometheus.Metric) error {
+	byDBRows, err := db.Query(fmt.Sprintf(infoSchemaProcesslistByDBQuery, *processlistByDBLimit))
+	if err != nil {
+		return err
+	}
+	defer byDBRows.Close()
+
+	var (
+		schema string
+		count  uint32
+	)
+	for byDBRows.Next() {
+		if err := byDBRows.Scan(&schema, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(processlistConnectionsByDBDesc, prometheus.GaugeValue, float64(count), schema)
+	}
+	return byDBRows.Err()
+}
+
+// scrapeProcesslistByCommand collects the number of connections by command.
+func scrapeProcesslistByCommand(db *sql.DB, ch chan<- prometheus.Metric) error {
+	byCommandRows, err := db.Query(infoSchemaProcesslistByCommandQuery)
+	if err != nil {
+		return err
+	}
+	defer byCommandRows.Close()
+
+	var (
+		command string
+		count   uint32
+	)
+	for byCommandRows.Next() {
+		if err := byCommandRows.Scan(&command, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(processlistConnectionsByCommandDesc, prometheus.GaugeValue, float64(count), command)
+	}
+	return byCommandRows.Err()
+}
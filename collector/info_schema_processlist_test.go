@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeProcesslistStateTime(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"command", "state", "le1", "le10", "le60", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Sleep", "", 3, 10, 20, 25).
+		AddRow("Query", "Sending data", 4, 5, 5, 7).
+		AddRow("Query", "executing", 1, 1, 1, 1).
+		AddRow("Query", "User lock", 0, 0, 1, 2)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(infoSchemaProcesslistStateTimeQuery, 0))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = scrapeProcesslistStateTime(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"state": "executing", "le": "1"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "executing", "le": "10"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "executing", "le": "60"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "executing", "le": "+Inf"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "idle", "le": "1"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "idle", "le": "10"}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "idle", "le": "60"}, value: 20, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "idle", "le": "+Inf"}, value: 25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "sending data", "le": "1"}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "sending data", "le": "10"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "sending data", "le": "60"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "sending data", "le": "+Inf"}, value: 7, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "waiting for lock", "le": "1"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "waiting for lock", "le": "10"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "waiting for lock", "le": "60"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "waiting for lock", "le": "+Inf"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeProcesslistByDB(t *testing.T) {
+	limit := *processlistByDBLimit
+	*processlistByDBLimit = 100
+	defer func() { *processlistByDBLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"db", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("tenant_a", 42).
+		AddRow("", 7).
+		AddRow("tenant_b", 3)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(infoSchemaProcesslistByDBQuery, 100))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = scrapeProcesslistByDB(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"db": "tenant_a"}, value: 42, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"db": ""}, value: 7, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"db": "tenant_b"}, value: 3, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeProcesslistByCommand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"command", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Binlog Dump GTID", 2).
+		AddRow("Daemon", 1).
+		AddRow("Query", 5).
+		AddRow("Sleep", 40)
+	mock.ExpectQuery(sanitizeQuery(infoSchemaProcesslistByCommandQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = scrapeProcesslistByCommand(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"command": "Binlog Dump GTID"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "Daemon"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "Query"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "Sleep"}, value: 40, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
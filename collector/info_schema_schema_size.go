@@ -0,0 +1,94 @@
+// Scrape per-schema size rollups from `information_schema.tables`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const schemaSizeQuery = `
+		SELECT
+		    TABLE_SCHEMA,
+		    COUNT(*) as TABLES,
+		    ifnull(SUM(DATA_LENGTH), 0) as DATA_LENGTH,
+		    ifnull(SUM(INDEX_LENGTH), 0) as INDEX_LENGTH,
+		    ifnull(SUM(DATA_FREE), 0) as DATA_FREE
+		  FROM information_schema.tables
+		  WHERE TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema')
+		  %s
+		  GROUP BY TABLE_SCHEMA
+		`
+
+// Metric descriptors.
+var (
+	infoSchemaSchemaTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "schema_tables"),
+		"The number of tables in the schema from information_schema.tables",
+		[]string{"schema"}, nil,
+	)
+	infoSchemaSchemaSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "schema_size"),
+		"The size of the schema components summed over its tables from information_schema.tables",
+		[]string{"schema", "component"}, nil,
+	)
+)
+
+// schemaFilter returns an additional WHERE condition restricting TABLE_SCHEMA
+// to the given database, or else to the databases given by
+// --collect.info_schema.tables.databases, along with its query arguments.
+func schemaFilter(database string) (string, []interface{}) {
+	if database != "" {
+		return databaseFilter("AND", database)
+	}
+	if *tableSchemaDatabases == "*" {
+		return "", nil
+	}
+	placeholders, args := listArgs(*tableSchemaDatabases)
+	return "AND TABLE_SCHEMA IN (" + placeholders + ")", args
+}
+
+// ScrapeSchemaSize collects per-schema size rollups from `information_schema.tables`.
+func ScrapeSchemaSize(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := schemaFilter(database)
+	schemaSizeRows, err := db.Query(fmt.Sprintf(schemaSizeQuery, filter), args...)
+	if err != nil {
+		return err
+	}
+	defer schemaSizeRows.Close()
+
+	var (
+		schema      string
+		tables      uint64
+		dataLength  uint64
+		indexLength uint64
+		dataFree    uint64
+	)
+
+	for schemaSizeRows.Next() {
+		if err := schemaSizeRows.Scan(
+			&schema, &tables, &dataLength, &indexLength, &dataFree,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaSchemaTablesDesc, prometheus.GaugeValue, float64(tables),
+			schema,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaSchemaSizeDesc, prometheus.GaugeValue, float64(dataLength),
+			schema, "data_length",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaSchemaSizeDesc, prometheus.GaugeValue, float64(indexLength),
+			schema, "index_length",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaSchemaSizeDesc, prometheus.GaugeValue, float64(dataFree),
+			schema, "data_free",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSchemaSize(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "*"
+	defer func() { *tableSchemaDatabases = databases }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"TABLE_SCHEMA", "TABLES", "DATA_LENGTH", "INDEX_LENGTH", "DATA_FREE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", 12, 1048576, 524288, 4096).
+		AddRow("shop", 3, 16384, 0, 0)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(schemaSizeQuery, ""))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSchemaSize(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "app"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "component": "data_length"}, value: 1048576, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "component": "index_length"}, value: 524288, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "component": "data_free"}, value: 4096, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "component": "data_length"}, value: 16384, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "component": "index_length"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "component": "data_free"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeSchemaSizeDatabases(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "app,shop"
+	defer func() { *tableSchemaDatabases = databases }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"TABLE_SCHEMA", "TABLES", "DATA_LENGTH", "INDEX_LENGTH", "DATA_FREE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", 12, 1048576, 524288, 4096)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(schemaSizeQuery, "AND TABLE_SCHEMA IN (?,?)"))).
+		WithArgs("app", "shop").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSchemaSize(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"schema": "app"}, value: 12, metricType: dto.MetricType_GAUGE})
+		for range ch {
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Scrape per-schema table counts from `information_schema.tables` along with
+// the table cache sizing from `SHOW GLOBAL VARIABLES`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	tableCache = "table_cache"
+	// Queries.
+	tableCacheSizesQuery        = `SELECT @@table_open_cache, @@table_definition_cache`
+	tableCacheSchemaTablesQuery = `
+		SELECT TABLE_SCHEMA, COUNT(*)
+		  FROM information_schema.tables
+		  WHERE TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema')
+		  %s
+		  GROUP BY TABLE_SCHEMA
+		`
+)
+
+// Metric descriptors.
+var (
+	tableCacheOpenSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "open_size"),
+		"The number of open tables for all threads from table_open_cache.",
+		nil, nil,
+	)
+	tableCacheDefinitionSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "definition_size"),
+		"The number of table definitions that can be cached from table_definition_cache.",
+		nil, nil,
+	)
+	tableCacheSchemaTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "schema_tables"),
+		"The number of tables in the schema competing for the table caches.",
+		[]string{"schema"}, nil,
+	)
+	tableCacheSchemaDefinitionRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "schema_definition_ratio"),
+		"Ratio of the tables in the schema to table_definition_cache, the cache thrashes as it approaches 1.",
+		[]string{"schema"}, nil,
+	)
+)
+
+// ScrapeSchemaCacheRisk collects the number of tables by schema alongside the
+// table cache sizes, to find the schemas with more tables than the caches hold.
+func ScrapeSchemaCacheRisk(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	var openCache, definitionCache float64
+	if err := db.QueryRow(tableCacheSizesQuery).Scan(&openCache, &definitionCache); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(tableCacheOpenSizeDesc, prometheus.GaugeValue, openCache)
+	ch <- prometheus.MustNewConstMetric(tableCacheDefinitionSizeDesc, prometheus.GaugeValue, definitionCache)
+
+	filter, args := schemaFilter(database)
+	schemaRows, err := db.Query(fmt.Sprintf(tableCacheSchemaTablesQuery, filter), args...)
+	if err != nil {
+		return err
+	}
+	defer schemaRows.Close()
+
+	var (
+		schema string
+		tables float64
+	)
+	for schemaRows.Next() {
+		if err := schemaRows.Scan(&schema, &tables); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			tableCacheSchemaTablesDesc, prometheus.GaugeValue, tables,
+			schema,
+		)
+		if definitionCache > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				tableCacheSchemaDefinitionRatioDesc, prometheus.GaugeValue, tables/definitionCache,
+				schema,
+			)
+		}
+	}
+	return schemaRows.Err()
+}
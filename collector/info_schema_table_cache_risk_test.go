@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSchemaCacheRisk(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "app,shop"
+	defer func() { *tableSchemaDatabases = databases }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableCacheSizesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@table_open_cache", "@@table_definition_cache"}).AddRow(4000, 2000))
+	columns := []string{"TABLE_SCHEMA", "COUNT(*)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", 3000).
+		AddRow("shop", 50)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(tableCacheSchemaTablesQuery, "AND TABLE_SCHEMA IN (?,?)"))).
+		WithArgs("app", "shop").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSchemaCacheRisk(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 4000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 2000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app"}, value: 3000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app"}, value: 1.5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop"}, value: 50, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop"}, value: 0.025, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
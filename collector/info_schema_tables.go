@@ -59,10 +59,13 @@ var (
 	)
 )
 
-// ScrapeTableSchema collects from `information_schema.tables`.
-func ScrapeTableSchema(db *sql.DB, ch chan<- prometheus.Metric, wg *sync.WaitGroup) error {
+// ScrapeTableSchema collects from `information_schema.tables`, only for the
+// given database if it is not empty.
+func ScrapeTableSchema(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
 	var dbList []string
-	if *tableSchemaDatabases == "*" {
+	if database != "" {
+		dbList = []string{database}
+	} else if *tableSchemaDatabases == "*" {
 		dbListRows, err := db.Query(dbListQuery)
 		if err != nil {
 			return err
@@ -83,71 +86,88 @@ func ScrapeTableSchema(db *sql.DB, ch chan<- prometheus.Metric, wg *sync.WaitGro
 		dbList = strings.Split(*tableSchemaDatabases, ",")
 	}
 
+	var (
+		wg       sync.WaitGroup
+		errMtx   sync.Mutex
+		firstErr error
+	)
 	for _, database := range dbList {
 		wg.Add(1)
-		go func(dbname string) error {
+		go func(dbname string) {
 			defer wg.Done()
-			tableSchemaRows, err := db.Query(fmt.Sprintf(tableSchemaQuery, dbname))
-			if err != nil {
-				return err
-			}
-			defer tableSchemaRows.Close()
-
-			var (
-				tableSchema   string
-				tableName     string
-				tableType     string
-				engine        string
-				version       uint64
-				rowFormat     string
-				tableRows     uint64
-				dataLength    uint64
-				indexLength   uint64
-				dataFree      uint64
-				createOptions string
-			)
-
-			for tableSchemaRows.Next() {
-				err = tableSchemaRows.Scan(
-					&tableSchema,
-					&tableName,
-					&tableType,
-					&engine,
-					&version,
-					&rowFormat,
-					&tableRows,
-					&dataLength,
-					&indexLength,
-					&dataFree,
-					&createOptions,
-				)
-				if err != nil {
-					return err
+			if err := scrapeTableSchemaDatabase(db, ch, dbname); err != nil {
+				errMtx.Lock()
+				if firstErr == nil {
+					firstErr = err
 				}
-				ch <- prometheus.MustNewConstMetric(
-					infoSchemaTablesVersionDesc, prometheus.GaugeValue, float64(version),
-					tableSchema, tableName, tableType, engine, rowFormat, createOptions,
-				)
-				ch <- prometheus.MustNewConstMetric(
-					infoSchemaTablesRowsDesc, prometheus.GaugeValue, float64(tableRows),
-					tableSchema, tableName,
-				)
-				ch <- prometheus.MustNewConstMetric(
-					infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(dataLength),
-					tableSchema, tableName, "data_length",
-				)
-				ch <- prometheus.MustNewConstMetric(
-					infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(indexLength),
-					tableSchema, tableName, "index_length",
-				)
-				ch <- prometheus.MustNewConstMetric(
-					infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(dataFree),
-					tableSchema, tableName, "data_free",
-				)
+				errMtx.Unlock()
 			}
-			return nil
 		}(database)
 	}
+	wg.Wait()
+
+	return firstErr
+}
 
+// scrapeTableSchemaDatabase collects the tables of a single database.
+func scrapeTableSchemaDatabase(db *sql.DB, ch chan<- prometheus.Metric, dbname string) error {
+	tableSchemaRows, err := db.Query(fmt.Sprintf(tableSchemaQuery, dbname))
+	if err != nil {
+		return err
+	}
+	defer tableSchemaRows.Close()
+
+	var (
+		tableSchema   string
+		tableName     string
+		tableType     string
+		engine        string
+		version       uint64
+		rowFormat     string
+		tableRows     uint64
+		dataLength    uint64
+		indexLength   uint64
+		dataFree      uint64
+		createOptions string
+	)
+
+	for tableSchemaRows.Next() {
+		err = tableSchemaRows.Scan(
+			&tableSchema,
+			&tableName,
+			&tableType,
+			&engine,
+			&version,
+			&rowFormat,
+			&tableRows,
+			&dataLength,
+			&indexLength,
+			&dataFree,
+			&createOptions,
+		)
+		if err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaTablesVersionDesc, prometheus.GaugeValue, float64(version),
+			tableSchema, tableName, tableType, engine, rowFormat, createOptions,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaTablesRowsDesc, prometheus.GaugeValue, float64(tableRows),
+			tableSchema, tableName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(dataLength),
+			tableSchema, tableName, "data_length",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(indexLength),
+			tableSchema, tableName, "index_length",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(dataFree),
+			tableSchema, tableName, "data_free",
+		)
+	}
 	return nil
 }
@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeTableSchemaDatabase(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "*"
+	defer func() { *tableSchemaDatabases = databases }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// Only the tables of the scoped database are queried, without listing all databases.
+	columns := []string{"TABLE_SCHEMA", "TABLE_NAME", "TABLE_TYPE", "ENGINE", "VERSION", "ROW_FORMAT", "TABLE_ROWS", "DATA_LENGTH", "INDEX_LENGTH", "DATA_FREE", "CREATE_OPTIONS"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "users", "BASE TABLE", "InnoDB", "10", "Dynamic", "100", "16384", "8192", "0", "")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(tableSchemaQuery, "app"))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTableSchema(db, ch, "app"); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "app", "table": "users", "type": "BASE TABLE", "engine": "InnoDB", "row_format": "Dynamic", "create_options": ""}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "table": "users"}, value: 100, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "table": "users", "component": "data_length"}, value: 16384, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "table": "users", "component": "index_length"}, value: 8192, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "app", "table": "users", "component": "data_free"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
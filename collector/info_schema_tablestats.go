@@ -4,6 +4,7 @@ package collector
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
@@ -17,6 +18,7 @@ const tableStatQuery = `
 		  ROWS_CHANGED,
 		  ROWS_CHANGED_X_INDEXES
 		  FROM information_schema.table_statistics
+		  %s
 		`
 
 var (
@@ -37,8 +39,9 @@ var (
 	)
 )
 
-// ScrapeTableStat collects from `information_schema.table_statistics`.
-func ScrapeTableStat(db *sql.DB, ch chan<- prometheus.Metric) error {
+// ScrapeTableStat collects from `information_schema.table_statistics`, only for
+// the given database if it is not empty.
+func ScrapeTableStat(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
 	var varName, varVal string
 	err := db.QueryRow(userstatCheckQuery).Scan(&varName, &varVal)
 	if err != nil {
@@ -50,7 +53,8 @@ func ScrapeTableStat(db *sql.DB, ch chan<- prometheus.Metric) error {
 		return nil
 	}
 
-	informationSchemaTableStatisticsRows, err := db.Query(tableStatQuery)
+	filter, args := databaseFilter("WHERE", database)
+	informationSchemaTableStatisticsRows, err := db.Query(fmt.Sprintf(tableStatQuery, filter), args...)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -23,11 +24,11 @@ func TestScrapeTableStat(t *testing.T) {
 		AddRow("mysql", "db", 238, 0, 8).
 		AddRow("mysql", "proxies_priv", 99, 1, 0).
 		AddRow("mysql", "user", 1064, 2, 5)
-	mock.ExpectQuery(sanitizeQuery(tableStatQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(tableStatQuery, ""))).WillReturnRows(rows)
 
 	ch := make(chan prometheus.Metric)
 	go func() {
-		if err = ScrapeTableStat(db, ch); err != nil {
+		if err = ScrapeTableStat(db, ch, ""); err != nil {
 			t.Errorf("error calling function on test: %s", err)
 		}
 		close(ch)
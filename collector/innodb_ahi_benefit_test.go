@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbAHIBenefit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	sample := `
+-------------------------------------
+INSERT BUFFER AND ADAPTIVE HASH INDEX
+-------------------------------------
+Ibuf: size 1, free list len 0, seg size 2, 0 merges
+Hash table size 34679, node heap has 2 buffer(s)
+Hash table size 34679, node heap has 0 buffer(s)
+750.00 hash searches/s, 250.00 non-hash searches/s
+----------------------
+BUFFER POOL AND MEMORY
+----------------------
+Total large memory allocated 137428992
+Buffer pool size   8191
+Buffer pool hit rate 980 / 1000, young-making rate 0 / 1000 not 0 / 1000
+----------------------
+INDIVIDUAL BUFFER POOL INFO
+----------------------
+---BUFFER POOL 0
+Buffer pool hit rate 990 / 1000, young-making rate 0 / 1000 not 0 / 1000
+`
+	columns := []string{"Type", "Name", "Status"}
+	rows := sqlmock.NewRows(columns).AddRow("InnoDB", "", sample)
+	mock.ExpectQuery(sanitizeQuery(engineInnodbStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbAHIBenefit(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 0.735, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got.value, convey.ShouldAlmostEqual, expect.value)
+			convey.So(got.metricType, convey.ShouldEqual, expect.metricType)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestInnodbAHIBenefitRatioIdle(t *testing.T) {
+	convey.Convey("No ratio without searches or page gets", t, func() {
+		_, ok := innodbAHIBenefitRatio("0.00 hash searches/s, 0.00 non-hash searches/s\nBuffer pool hit rate 1000 / 1000")
+		convey.So(ok, convey.ShouldBeFalse)
+		_, ok = innodbAHIBenefitRatio("10.00 hash searches/s, 0.00 non-hash searches/s\nNo buffer pool page gets since the last printout")
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+}
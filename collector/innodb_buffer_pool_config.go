@@ -0,0 +1,152 @@
+// Scrape the InnoDB buffer pool sizing from `SHOW GLOBAL VARIABLES` and the
+// online resize progress from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	innodbBufferPoolConfigVariablesQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN ('innodb_buffer_pool_size', 'innodb_buffer_pool_instances', 'innodb_buffer_pool_chunk_size')
+		`
+	// Innodb_buffer_pool_resize_status_code was added in MySQL 8.0.31.
+	innodbBufferPoolResizeStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Innodb_buffer_pool_resize_status', 'Innodb_buffer_pool_resize_status_code')
+		`
+)
+
+// Metric descriptors.
+var (
+	innodbBufferPoolConfigDescs = map[string]*prometheus.Desc{
+		"innodb_buffer_pool_size": prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, innodbSubsystem, "buffer_pool_size_bytes"),
+			"The configured size of the buffer pool.",
+			nil, nil,
+		),
+		"innodb_buffer_pool_instances": prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, innodbSubsystem, "buffer_pool_instances"),
+			"The number of regions the buffer pool is divided into.",
+			nil, nil,
+		),
+		"innodb_buffer_pool_chunk_size": prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, innodbSubsystem, "buffer_pool_chunk_size_bytes"),
+			"The size of the chunks the buffer pool is resized by.",
+			nil, nil,
+		),
+	}
+	innodbBufferPoolResizeInProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "buffer_pool_resize_in_progress"),
+		"Whether an online resize of the buffer pool is in progress.",
+		nil, nil,
+	)
+	innodbBufferPoolResizeFailedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "buffer_pool_resize_failed"),
+		"Whether the last online resize of the buffer pool failed, from Innodb_buffer_pool_resize_status_code.",
+		nil, nil,
+	)
+)
+
+// bufferPoolResizeFailedCode is the Innodb_buffer_pool_resize_status_code of a
+// failed resize, 0 meaning none is in progress and the others its stages.
+const bufferPoolResizeFailedCode = "7"
+
+// ScrapeInnodbBufferPoolConfig collects the buffer pool sizing along with
+// whether an online resize is in progress.
+func ScrapeInnodbBufferPoolConfig(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variableRows, err := db.Query(innodbBufferPoolConfigVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer variableRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for variableRows.Next() {
+		if err := variableRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		desc, ok := innodbBufferPoolConfigDescs[key]
+		if !ok {
+			continue
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, floatVal)
+		}
+	}
+	if err := variableRows.Err(); err != nil {
+		return err
+	}
+
+	statusRows, err := db.Query(innodbBufferPoolResizeStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		status, statusCode   string
+		haveStatus, haveCode bool
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		switch key {
+		case "Innodb_buffer_pool_resize_status":
+			status, haveStatus = string(val), true
+		case "Innodb_buffer_pool_resize_status_code":
+			statusCode, haveCode = string(val), true
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	var inProgress bool
+	switch {
+	case haveCode:
+		inProgress = statusCode != "0" && statusCode != bufferPoolResizeFailedCode
+		failedVal := 0.0
+		if statusCode == bufferPoolResizeFailedCode {
+			failedVal = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			innodbBufferPoolResizeFailedDesc, prometheus.GaugeValue, failedVal,
+		)
+	case haveStatus:
+		inProgress = bufferPoolResizeInProgress(status)
+	default:
+		return nil
+	}
+	resizeVal := 0.0
+	if inProgress {
+		resizeVal = 1
+	}
+	ch <- prometheus.MustNewConstMetric(
+		innodbBufferPoolResizeInProgressDesc, prometheus.GaugeValue, resizeVal,
+	)
+	return nil
+}
+
+// bufferPoolResizeInProgress parses Innodb_buffer_pool_resize_status, which is
+// empty before the first resize and keeps the last message once it is done.
+func bufferPoolResizeInProgress(status string) bool {
+	switch {
+	case status == "":
+		return false
+	case strings.HasPrefix(status, "Completed resizing buffer pool"):
+		return false
+	case strings.Contains(status, "Nothing to do"):
+		return false
+	}
+	return true
+}
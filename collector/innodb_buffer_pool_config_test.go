@@ -0,0 +1,122 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbBufferPoolConfig(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("innodb_buffer_pool_chunk_size", "134217728").
+		AddRow("innodb_buffer_pool_instances", "8").
+		AddRow("innodb_buffer_pool_size", "8589934592")
+	mock.ExpectQuery(sanitizeQuery(innodbBufferPoolConfigVariablesQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows(columns).
+		AddRow("Innodb_buffer_pool_resize_status", "Resizing buffer pool from 8589934592 to 17179869184 (unit=134217728).")
+	mock.ExpectQuery(sanitizeQuery(innodbBufferPoolResizeStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbBufferPoolConfig(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 134217728, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 8, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 8589934592, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeInnodbBufferPoolConfigResizeCode(t *testing.T) {
+	columns := []string{"Variable_name", "Value"}
+	for code, expect := range map[string][]MetricResult{
+		"0": {
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		},
+		"5": {
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		},
+		// A failed resize is no longer in progress.
+		"7": {
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		},
+	} {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+
+		mock.ExpectQuery(sanitizeQuery(innodbBufferPoolConfigVariablesQuery)).WillReturnRows(sqlmock.NewRows(columns))
+		rows := sqlmock.NewRows(columns).
+			AddRow("Innodb_buffer_pool_resize_status", "").
+			AddRow("Innodb_buffer_pool_resize_status_code", code)
+		mock.ExpectQuery(sanitizeQuery(innodbBufferPoolResizeStatusQuery)).WillReturnRows(rows)
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err := ScrapeInnodbBufferPoolConfig(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		var got []MetricResult
+		for m := range ch {
+			got = append(got, readMetric(m))
+		}
+		convey.Convey("Resize status code "+code, t, func() {
+			convey.So(got, convey.ShouldResemble, expect)
+		})
+
+		// Ensure all SQL queries were executed
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expections: %s", err)
+		}
+		db.Close()
+	}
+}
+
+func TestBufferPoolResizeInProgress(t *testing.T) {
+	convey.Convey("Resize status parsing", t, func() {
+		for status, expect := range map[string]bool{
+			"": false,
+			"Resizing buffer pool from 134217728 to 268435456 (unit=134217728).":   true,
+			"Completed resizing buffer pool at 220826  6:57:46.":                   false,
+			"Size did not change (old size = new size = 134217728. Nothing to do.": false,
+			"Withdrawing blocks to be shrunken.":                                   true,
+		} {
+			convey.So(bufferPoolResizeInProgress(status), convey.ShouldEqual, expect)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// Scrape InnoDB buffer pool dump/load progress.
+
+package collector
+
+import (
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Queries.
+	innodbBufferPoolDumpStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Innodb_buffer_pool_dump_status', 'Innodb_buffer_pool_load_status')
+		`
+	innodbBufferPoolDumpVariablesQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN ('innodb_buffer_pool_dump_at_shutdown', 'innodb_buffer_pool_load_at_startup')
+		`
+	// The completion times are in the time zone of the server's system,
+	// UNIX_TIMESTAMP interprets them in the session time zone.
+	innodbBufferPoolCompletedQuery = `SELECT UNIX_TIMESTAMP(CONVERT_TZ(?, 'SYSTEM', @@session.time_zone))`
+)
+
+// Regexps for the progress strings, e.g. "Loaded 1024/2048 pages" or
+// "Dumping buffer pool 1/8, page 100/4096", and the completion time.
+var (
+	bufferPoolProgressRE   = regexp.MustCompile(`(?:(\d+)/(\d+) pages|page (\d+)/(\d+))`)
+	bufferPoolCompletedRE  = regexp.MustCompile(`completed at (\d{6} \d{2}:\d{2}:\d{2}|\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})`)
+	bufferPoolNotStartedRE = regexp.MustCompile(`not (yet )?started`)
+)
+
+// parseBufferPoolProgress returns the progress ratio of a buffer pool dump or
+// load status string.
+func parseBufferPoolProgress(status string) (float64, bool) {
+	if bufferPoolCompletedRE.MatchString(status) {
+		return 1, true
+	}
+	if bufferPoolNotStartedRE.MatchString(status) {
+		return 0, true
+	}
+	match := bufferPoolProgressRE.FindStringSubmatch(status)
+	if match == nil {
+		return 0, false
+	}
+	done, total := match[1], match[2]
+	if done == "" {
+		done, total = match[3], match[4]
+	}
+	doneVal, err := strconv.ParseFloat(done, 64)
+	if err != nil {
+		return 0, false
+	}
+	totalVal, err := strconv.ParseFloat(total, 64)
+	if err != nil || totalVal == 0 {
+		return 0, false
+	}
+	return doneVal / totalVal, true
+}
+
+// parseBufferPoolCompleted returns the completion time of a buffer pool dump
+// or load status string as a "2006-01-02 15:04:05" time of the server's
+// system time zone.
+func parseBufferPoolCompleted(status string) (string, bool) {
+	match := bufferPoolCompletedRE.FindStringSubmatch(status)
+	if match == nil {
+		return "", false
+	}
+	layout := "060102 15:04:05"
+	if strings.Contains(match[1], "-") {
+		layout = "2006-01-02 15:04:05"
+	}
+	completed, err := time.Parse(layout, match[1])
+	if err != nil {
+		return "", false
+	}
+	return completed.Format("2006-01-02 15:04:05"), true
+}
+
+// ScrapeInnodbBufferPoolDump collects InnoDB buffer pool dump/load progress.
+func ScrapeInnodbBufferPoolDump(db *sql.DB, ch chan<- prometheus.Metric) error {
+	statusRows, err := db.Query(innodbBufferPoolDumpStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var key, val string
+	completedTimes := map[string]string{}
+
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		operation := "dump"
+		if strings.ToLower(key) == "innodb_buffer_pool_load_status" {
+			operation = "load"
+		}
+		if ratio, ok := parseBufferPoolProgress(val); ok {
+			ch <- prometheus.MustNewConstMetric(
+				newDesc(innodbSubsystem, "buffer_pool_"+operation+"_progress_ratio", "Progress of the current InnoDB buffer pool "+operation+", 1 once completed."),
+				prometheus.GaugeValue,
+				ratio,
+			)
+		}
+		if completed, ok := parseBufferPoolCompleted(val); ok {
+			completedTimes[operation] = completed
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	for _, operation := range []string{"dump", "load"} {
+		completed, ok := completedTimes[operation]
+		if !ok {
+			continue
+		}
+		var timestamp sql.NullFloat64
+		if err := db.QueryRow(innodbBufferPoolCompletedQuery, completed).Scan(&timestamp); err != nil {
+			return err
+		}
+		// CONVERT_TZ returns NULL for time zones it does not know.
+		if !timestamp.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			newDesc(innodbSubsystem, "buffer_pool_"+operation+"_completed_timestamp_seconds", "Time the last InnoDB buffer pool "+operation+" completed."),
+			prometheus.GaugeValue,
+			timestamp.Float64,
+		)
+	}
+
+	variablesRows, err := db.Query(innodbBufferPoolDumpVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer variablesRows.Close()
+
+	var value sql.RawBytes
+
+	for variablesRows.Next() {
+		if err := variablesRows.Scan(&key, &value); err != nil {
+			return err
+		}
+		if floatVal, ok := parseStatus(value); ok {
+			ch <- prometheus.MustNewConstMetric(
+				newDesc(innodbSubsystem, strings.TrimPrefix(strings.ToLower(key), "innodb_"), "Whether @@"+strings.ToLower(key)+" is enabled."),
+				prometheus.GaugeValue,
+				floatVal,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbBufferPoolDump(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Innodb_buffer_pool_dump_status", "Dumping buffer pool 1/1, page 512/2048").
+		AddRow("Innodb_buffer_pool_load_status", "Buffer pool(s) load completed at 171026 12:51:02")
+	mock.ExpectQuery(sanitizeQuery(innodbBufferPoolDumpStatusQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(innodbBufferPoolCompletedQuery)).WithArgs("2017-10-26 12:51:02").
+		WillReturnRows(sqlmock.NewRows([]string{"UNIX_TIMESTAMP"}).AddRow(1509022262))
+
+	rows = sqlmock.NewRows(columns).
+		AddRow("innodb_buffer_pool_dump_at_shutdown", "ON").
+		AddRow("innodb_buffer_pool_load_at_startup", "OFF")
+	mock.ExpectQuery(sanitizeQuery(innodbBufferPoolDumpVariablesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbBufferPoolDump(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1509022262, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestParseBufferPoolCompleted(t *testing.T) {
+	convey.Convey("Buffer pool completion times", t, func() {
+		for status, expect := range map[string]string{
+			"Buffer pool(s) dump completed at 180101 12:00:00":     "2018-01-01 12:00:00",
+			"Buffer pool(s) load completed at 2020-01-01 12:00:00": "2020-01-01 12:00:00",
+		} {
+			completed, ok := parseBufferPoolCompleted(status)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(completed, convey.ShouldEqual, expect)
+		}
+		_, ok := parseBufferPoolCompleted("Loaded 1024/2048 pages")
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+}
+
+func TestParseBufferPoolProgress(t *testing.T) {
+	convey.Convey("Buffer pool progress strings", t, func() {
+		for status, expect := range map[string]float64{
+			"Dumping of buffer pool not started":                   0,
+			"Loading buffer pool(s) not yet started":               0,
+			"Loaded 1024/2048 pages":                               0.5,
+			"Dumping buffer pool 3/8, page 100/400":                0.25,
+			"Buffer pool(s) dump completed at 180101 12:00:00":     1,
+			"Buffer pool(s) load completed at 2020-01-01 12:00:00": 1,
+		} {
+			ratio, ok := parseBufferPoolProgress(status)
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(ratio, convey.ShouldEqual, expect)
+		}
+		for _, status := range []string{"", "Loading buffer pool(s) from /var/lib/mysql/ib_buffer_pool", "Loaded 0/0 pages"} {
+			_, ok := parseBufferPoolProgress(status)
+			convey.So(ok, convey.ShouldBeFalse)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Scrape the InnoDB checkpoint age from `information_schema.innodb_metrics`,
+// falling back to `SHOW ENGINE INNODB STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const innodbCheckpointMetricsQuery = `
+		SELECT name, count
+		  FROM information_schema.innodb_metrics
+		  WHERE name IN ('log_lsn_checkpoint_age', 'log_max_modified_age_sync')
+		    AND status = 'enabled'
+		`
+
+// Regexps of the LOG section of `SHOW ENGINE INNODB STATUS`, the checkpoint
+// age lines are only printed by Percona Server.
+var (
+	innodbLogSequenceNumberRE = regexp.MustCompile(`^Log sequence number\s+(\d+)$`)
+	innodbLastCheckpointRE    = regexp.MustCompile(`^Last checkpoint at\s+(\d+)$`)
+	innodbCheckpointAgeRE     = regexp.MustCompile(`^Checkpoint age\s+(\d+)$`)
+	innodbMaxCheckpointAgeRE  = regexp.MustCompile(`^Max checkpoint age\s+(\d+)$`)
+)
+
+// Metric descriptors.
+var (
+	innodbCheckpointAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "checkpoint_age_bytes"),
+		"Amount of redo log written since the last checkpoint.",
+		nil, nil,
+	)
+	innodbCheckpointMaxAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "checkpoint_max_age_bytes"),
+		"Checkpoint age at which InnoDB flushes synchronously.",
+		nil, nil,
+	)
+	innodbCheckpointAgeRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "checkpoint_age_ratio"),
+		"Ratio of the checkpoint age to the maximum checkpoint age.",
+		nil, nil,
+	)
+)
+
+// ScrapeInnodbCheckpoint collects the InnoDB checkpoint age and how close it
+// is to forcing synchronous flushing.
+func ScrapeInnodbCheckpoint(db *sql.DB, ch chan<- prometheus.Metric) error {
+	age, maxAge, err := innodbCheckpointFromMetrics(db)
+	if err != nil {
+		return err
+	}
+	if age < 0 {
+		var typeCol, nameCol, statusCol string
+		if err := db.QueryRow(engineInnodbStatusQuery).Scan(&typeCol, &nameCol, &statusCol); err != nil {
+			return err
+		}
+		age, maxAge = parseInnodbCheckpoint(statusCol)
+		if age < 0 {
+			return nil
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(innodbCheckpointAgeDesc, prometheus.GaugeValue, age)
+	if maxAge > 0 {
+		ch <- prometheus.MustNewConstMetric(innodbCheckpointMaxAgeDesc, prometheus.GaugeValue, maxAge)
+		ch <- prometheus.MustNewConstMetric(innodbCheckpointAgeRatioDesc, prometheus.GaugeValue, age/maxAge)
+	}
+	return nil
+}
+
+// innodbCheckpointFromMetrics returns the checkpoint age and its maximum from
+// `information_schema.innodb_metrics`, or -1 unless both counters are enabled.
+func innodbCheckpointFromMetrics(db *sql.DB) (age, maxAge float64, err error) {
+	rows, err := db.Query(innodbCheckpointMetricsQuery)
+	if err != nil {
+		return -1, -1, err
+	}
+	defer rows.Close()
+
+	age, maxAge = -1, -1
+	var (
+		name  string
+		value float64
+	)
+	for rows.Next() {
+		if err := rows.Scan(&name, &value); err != nil {
+			return -1, -1, err
+		}
+		switch name {
+		case "log_lsn_checkpoint_age":
+			age = value
+		case "log_max_modified_age_sync":
+			maxAge = value
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return -1, -1, err
+	}
+	if age < 0 || maxAge < 0 {
+		return -1, -1, nil
+	}
+	return age, maxAge, nil
+}
+
+// parseInnodbCheckpoint returns the checkpoint age and, on Percona Server, its
+// maximum from the output of `SHOW ENGINE INNODB STATUS`. Values not found
+// are -1.
+func parseInnodbCheckpoint(status string) (age, maxAge float64) {
+	age, maxAge = -1, -1
+	lsn, checkpoint := -1.0, -1.0
+	for _, line := range strings.Split(status, "\n") {
+		line = strings.TrimSpace(line)
+		if data := innodbLogSequenceNumberRE.FindStringSubmatch(line); data != nil {
+			lsn, _ = strconv.ParseFloat(data[1], 64)
+		} else if data := innodbLastCheckpointRE.FindStringSubmatch(line); data != nil {
+			checkpoint, _ = strconv.ParseFloat(data[1], 64)
+		} else if data := innodbCheckpointAgeRE.FindStringSubmatch(line); data != nil {
+			age, _ = strconv.ParseFloat(data[1], 64)
+		} else if data := innodbMaxCheckpointAgeRE.FindStringSubmatch(line); data != nil {
+			maxAge, _ = strconv.ParseFloat(data[1], 64)
+		}
+	}
+	if age < 0 && lsn >= 0 && checkpoint >= 0 && lsn >= checkpoint {
+		age = lsn - checkpoint
+	}
+	return age, maxAge
+}
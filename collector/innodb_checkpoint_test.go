@@ -0,0 +1,113 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbCheckpointMetrics(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"name", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("log_lsn_checkpoint_age", "75000000").
+		AddRow("log_max_modified_age_sync", "100000000")
+	mock.ExpectQuery(sanitizeQuery(innodbCheckpointMetricsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbCheckpoint(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 75000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 100000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.75, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeInnodbCheckpointStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// The counters are disabled.
+	mock.ExpectQuery(sanitizeQuery(innodbCheckpointMetricsQuery)).WillReturnRows(sqlmock.NewRows([]string{"name", "count"}))
+	status := `
+---
+LOG
+---
+Log sequence number 1000500000
+Log flushed up to   1000500000
+Pages flushed up to 1000000000
+Last checkpoint at  1000000000
+Max checkpoint age    2000000
+Checkpoint age target 1800000
+Modified age          500000
+Checkpoint age        500000
+0 pending log flushes, 0 pending chkp writes
+`
+	mock.ExpectQuery(sanitizeQuery(engineInnodbStatusQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"Type", "Name", "Status"}).AddRow("InnoDB", "", status))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbCheckpoint(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 500000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 2000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestParseInnodbCheckpoint(t *testing.T) {
+	convey.Convey("Checkpoint age from MySQL status without Percona lines", t, func() {
+		age, maxAge := parseInnodbCheckpoint("Log sequence number          1000500000\nLast checkpoint at           1000000000\n")
+		convey.So(age, convey.ShouldEqual, 500000)
+		convey.So(maxAge, convey.ShouldEqual, -1)
+	})
+}
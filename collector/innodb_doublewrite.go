@@ -0,0 +1,93 @@
+// Scrape InnoDB doublewrite buffer activity from `SHOW GLOBAL STATUS` and
+// `information_schema.innodb_metrics`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	innodbDoublewriteStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Innodb_dblwr_writes', 'Innodb_dblwr_pages_written')
+		`
+	// The dblwr module of innodb_metrics was added in MySQL 8.0.20.
+	innodbDoublewriteMetricsQuery = `
+		SELECT name, count
+		  FROM information_schema.innodb_metrics
+		  WHERE subsystem = 'dblwr' AND status = 'enabled'
+		`
+)
+
+// Metric descriptors.
+var (
+	innodbDoublewriteWritesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_writes_total"),
+		"Total number of doublewrite operations.",
+		nil, nil,
+	)
+	innodbDoublewritePagesWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_pages_written_total"),
+		"Total number of pages written to the doublewrite buffer.",
+		nil, nil,
+	)
+	innodbDoublewriteMetricDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_metric_total"),
+		"Doublewrite counter from information_schema.innodb_metrics by name.",
+		[]string{"name"}, nil,
+	)
+)
+
+// ScrapeInnodbDoublewrite collects the doublewrite buffer writes along with the
+// enabled doublewrite counters of innodb_metrics.
+func ScrapeInnodbDoublewrite(db *sql.DB, ch chan<- prometheus.Metric) error {
+	statusRows, err := db.Query(innodbDoublewriteStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Innodb_dblwr_writes":
+			ch <- prometheus.MustNewConstMetric(innodbDoublewriteWritesDesc, prometheus.CounterValue, floatVal)
+		case "Innodb_dblwr_pages_written":
+			ch <- prometheus.MustNewConstMetric(innodbDoublewritePagesWrittenDesc, prometheus.CounterValue, floatVal)
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	metricRows, err := db.Query(innodbDoublewriteMetricsQuery)
+	if err != nil {
+		return err
+	}
+	defer metricRows.Close()
+
+	var (
+		name  string
+		count float64
+	)
+	for metricRows.Next() {
+		if err := metricRows.Scan(&name, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(innodbDoublewriteMetricDesc, prometheus.CounterValue, count, name)
+	}
+	return metricRows.Err()
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbDoublewrite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(innodbDoublewriteStatusQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("Innodb_dblwr_pages_written", "64000").
+		AddRow("Innodb_dblwr_writes", "1200"))
+	mock.ExpectQuery(sanitizeQuery(innodbDoublewriteMetricsQuery)).WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).
+		AddRow("dblwr_async_requests", 60000).
+		AddRow("dblwr_sync_requests", 4000))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbDoublewrite(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 64000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 1200, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"name": "dblwr_async_requests"}, value: 60000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"name": "dblwr_sync_requests"}, value: 4000, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Scrape the pages flushed by each InnoDB flushing mechanism from
+// `information_schema.innodb_metrics`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const innodbFlushMetricsQuery = `
+		SELECT name, count
+		  FROM information_schema.innodb_metrics
+		  WHERE name IN ('buffer_flush_adaptive_total_pages', 'buffer_LRU_batch_flush_total_pages', 'buffer_flush_background_total_pages')
+		    AND status = 'enabled'
+		`
+
+// innodbFlushMechanisms maps the innodb_metrics counters to the flushing
+// mechanism they count the pages of.
+var innodbFlushMechanisms = map[string]string{
+	"buffer_flush_adaptive_total_pages":   "adaptive",
+	"buffer_LRU_batch_flush_total_pages":  "lru",
+	"buffer_flush_background_total_pages": "background",
+}
+
+// Metric descriptors.
+var (
+	innodbFlushedPagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "flushed_pages_total"),
+		"Total number of pages flushed from the buffer pool by flushing mechanism.",
+		[]string{"mechanism"}, nil,
+	)
+)
+
+// ScrapeInnodbFlush collects the pages flushed by adaptive, LRU and background
+// flushing. Counters disabled in innodb_monitor_enable are skipped.
+func ScrapeInnodbFlush(db *sql.DB, ch chan<- prometheus.Metric) error {
+	flushRows, err := db.Query(innodbFlushMetricsQuery)
+	if err != nil {
+		return err
+	}
+	defer flushRows.Close()
+
+	var (
+		name  string
+		pages float64
+	)
+	for flushRows.Next() {
+		if err := flushRows.Scan(&name, &pages); err != nil {
+			return err
+		}
+		mechanism, ok := innodbFlushMechanisms[name]
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(innodbFlushedPagesDesc, prometheus.CounterValue, pages, mechanism)
+	}
+	return flushRows.Err()
+}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbFlush(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"name", "count"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("buffer_flush_adaptive_total_pages", 120000).
+		AddRow("buffer_LRU_batch_flush_total_pages", 450000).
+		AddRow("buffer_flush_background_total_pages", 3000)
+	mock.ExpectQuery(sanitizeQuery(innodbFlushMetricsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbFlush(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"mechanism": "adaptive"}, value: 120000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"mechanism": "lru"}, value: 450000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"mechanism": "background"}, value: 3000, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
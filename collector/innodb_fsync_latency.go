@@ -0,0 +1,96 @@
+// Scrape the latency of the miscellaneous operations, syncs among them, of the
+// InnoDB redo log and data files from
+// `performance_schema.file_summary_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// Query to count the enabled and timed InnoDB file instruments.
+	innodbFileInstrumentsQuery = `
+		SELECT COUNT(*)
+		  FROM performance_schema.setup_instruments
+		  WHERE NAME IN ('wait/io/file/innodb/innodb_log_file', 'wait/io/file/innodb/innodb_data_file')
+		    AND ENABLED = 'YES' AND TIMED = 'YES'
+		`
+	// Syncs are summarized as miscellaneous operations, along with the far
+	// cheaper opens, closes and stats.
+	innodbFsyncLatencyQuery = `
+		SELECT EVENT_NAME, COUNT_MISC, SUM_TIMER_MISC
+		  FROM performance_schema.file_summary_by_event_name
+		  WHERE EVENT_NAME IN ('wait/io/file/innodb/innodb_log_file', 'wait/io/file/innodb/innodb_data_file')
+		`
+)
+
+// Metric descriptors. Performance schema does not tell syncs apart from the
+// other miscellaneous operations, so the metrics are named after the latter.
+var (
+	innodbFileMiscOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "file_misc_ops_total"),
+		"The total number of miscellaneous operations of the InnoDB files, i.e. syncs, opens, closes and stats.",
+		[]string{"file"}, nil,
+	)
+	innodbFileMiscOpsTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "file_misc_ops_seconds_total"),
+		"The total time of miscellaneous operations of the InnoDB files, i.e. syncs, opens, closes and stats.",
+		[]string{"file"}, nil,
+	)
+	innodbFileMiscOpLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "file_misc_op_latency_seconds"),
+		"The average latency of miscellaneous operations of the InnoDB files since the server started, i.e. syncs, opens, closes and stats.",
+		[]string{"file"}, nil,
+	)
+)
+
+// ScrapeInnodbFsyncLatency collects the latency of the miscellaneous
+// operations, which are dominated by syncs, of the InnoDB redo log and data
+// files.
+func ScrapeInnodbFsyncLatency(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var instruments int
+	if err := db.QueryRow(innodbFileInstrumentsQuery).Scan(&instruments); err != nil {
+		return err
+	}
+	if instruments == 0 {
+		log.Debugln("InnoDB file I/O instruments are not enabled.")
+		return nil
+	}
+
+	fsyncRows, err := db.Query(innodbFsyncLatencyQuery)
+	if err != nil {
+		return err
+	}
+	defer fsyncRows.Close()
+
+	var (
+		eventName   string
+		count, time uint64
+	)
+	for fsyncRows.Next() {
+		if err := fsyncRows.Scan(&eventName, &count, &time); err != nil {
+			return err
+		}
+		file := strings.TrimPrefix(eventName, "wait/io/file/innodb/")
+		ch <- prometheus.MustNewConstMetric(
+			innodbFileMiscOpsDesc, prometheus.CounterValue, float64(count),
+			file,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			innodbFileMiscOpsTimeDesc, prometheus.CounterValue, float64(time)/picoSeconds,
+			file,
+		)
+		if count > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				innodbFileMiscOpLatencyDesc, prometheus.GaugeValue, float64(time)/picoSeconds/float64(count),
+				file,
+			)
+		}
+	}
+	return fsyncRows.Err()
+}
@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbFsyncLatency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbFileInstrumentsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	columns := []string{"EVENT_NAME", "COUNT_MISC", "SUM_TIMER_MISC"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("wait/io/file/innodb/innodb_data_file", 0, 0).
+		AddRow("wait/io/file/innodb/innodb_log_file", 4000, 2000000000000)
+	mock.ExpectQuery(sanitizeQuery(innodbFsyncLatencyQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbFsyncLatency(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"file": "innodb_data_file"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "innodb_data_file"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "innodb_log_file"}, value: 4000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "innodb_log_file"}, value: 2, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "innodb_log_file"}, value: 0.0005, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,194 @@
+// Scrape InnoDB row lock waits from `SHOW ENGINE INNODB STATUS` and
+// `SHOW GLOBAL STATUS`, and metadata lock waits from
+// `information_schema.processlist`.
+
+package collector
+
+import (
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	lockWaitTimeoutsQuery          = `SELECT @@innodb_lock_wait_timeout, @@lock_wait_timeout`
+	innodbRowLockCurrentWaitsQuery = `SHOW GLOBAL STATUS LIKE 'Innodb_row_lock_current_waits'`
+	metadataLockWaitsQuery         = `
+		SELECT ID, TIME
+		  FROM information_schema.processlist
+		  WHERE STATE LIKE 'Waiting for %metadata lock'
+		`
+)
+
+// Regexps for the TRANSACTIONS section of `SHOW ENGINE INNODB STATUS`.
+var (
+	innodbTransactionRE = regexp.MustCompile(`^---TRANSACTION (\d+),`)
+	innodbLockWaitingRE = regexp.MustCompile(`TRX HAS BEEN WAITING (\d+) SEC FOR THIS LOCK TO BE GRANTED`)
+)
+
+// Metric descriptors.
+var (
+	innodbLockWaitingTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "lock_waiting_transactions"),
+		"Number of transactions currently waiting for a row lock, from Innodb_row_lock_current_waits.",
+		nil, nil,
+	)
+	innodbLockWaitLongestDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "lock_wait_longest_seconds"),
+		"Longest time a transaction has been waiting for a row lock.",
+		nil, nil,
+	)
+	innodbLockWaitTimeoutsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "lock_wait_timeouts_estimated_total"),
+		"Estimated number of row lock waits that ended by reaching @@innodb_lock_wait_timeout, approximate in both directions.",
+		nil, nil,
+	)
+	metadataLockWaitingThreadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "metadata_lock_waiting_threads"),
+		"Number of threads currently waiting for a metadata lock.",
+		nil, nil,
+	)
+	metadataLockWaitTimeoutsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "metadata_lock_wait_timeouts_estimated_total"),
+		"Estimated number of metadata lock waits that ended by reaching @@lock_wait_timeout, approximate in both directions.",
+		nil, nil,
+	)
+)
+
+// lockWaitTracker estimates lock wait timeouts from the lock waits seen by
+// consecutive scrapes. Waits are only visible while they last, so a timeout is
+// counted when a waiter disappears after it could have reached the timeout.
+// This is an approximation in both directions: waits starting and ending
+// between two scrapes are never seen, while a waiter that was granted its lock
+// or was killed once it could have reached the timeout is counted, which after
+// a long gap between scrapes is every waiter that disappeared.
+type lockWaitTracker struct {
+	mtx      sync.Mutex
+	waits    map[string]float64
+	seen     time.Time
+	timeouts float64
+}
+
+// update records the waiting time by waiter seen at now, returning the
+// estimated number of timeouts so far.
+func (t *lockWaitTracker) update(waits map[string]float64, timeout float64, now time.Time) float64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if !t.seen.IsZero() {
+		elapsed := now.Sub(t.seen).Seconds()
+		for waiter, wait := range t.waits {
+			if _, ok := waits[waiter]; !ok && wait+elapsed >= timeout {
+				t.timeouts++
+			}
+		}
+	}
+	t.waits = waits
+	t.seen = now
+	return t.timeouts
+}
+
+// reset forgets the waits seen so far.
+func (t *lockWaitTracker) reset() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.waits = nil
+	t.seen = time.Time{}
+	t.timeouts = 0
+}
+
+var (
+	innodbLockWaits   lockWaitTracker
+	metadataLockWaits lockWaitTracker
+	lockWaitsTimeNow  = time.Now
+)
+
+// parseInnodbLockWaits returns the waiting time by transaction id from the
+// output of `SHOW ENGINE INNODB STATUS`.
+func parseInnodbLockWaits(status string) map[string]float64 {
+	waits := map[string]float64{}
+	trx := ""
+	for _, line := range strings.Split(status, "\n") {
+		if data := innodbTransactionRE.FindStringSubmatch(line); data != nil {
+			trx = data[1]
+		} else if data := innodbLockWaitingRE.FindStringSubmatch(line); data != nil && trx != "" {
+			waits[trx], _ = strconv.ParseFloat(data[1], 64)
+		}
+	}
+	return waits
+}
+
+// ScrapeLockTimeouts collects current InnoDB row lock and metadata lock waits
+// and estimates the lock wait timeouts of both. The number of row lock waiters
+// comes from Innodb_row_lock_current_waits, as the transaction list of
+// `SHOW ENGINE INNODB STATUS` is truncated on busy servers.
+func ScrapeLockTimeouts(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var innodbTimeout, metadataTimeout float64
+	if err := db.QueryRow(lockWaitTimeoutsQuery).Scan(&innodbTimeout, &metadataTimeout); err != nil {
+		return err
+	}
+	now := lockWaitsTimeNow()
+
+	var typeCol, nameCol, statusCol string
+	if err := db.QueryRow(engineInnodbStatusQuery).Scan(&typeCol, &nameCol, &statusCol); err != nil {
+		return err
+	}
+	waits := parseInnodbLockWaits(statusCol)
+	longest := float64(0)
+	for _, wait := range waits {
+		if wait > longest {
+			longest = wait
+		}
+	}
+
+	var (
+		key          string
+		currentWaits float64
+	)
+	if err := db.QueryRow(innodbRowLockCurrentWaitsQuery).Scan(&key, &currentWaits); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		innodbLockWaitingTransactionsDesc, prometheus.GaugeValue, currentWaits,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		innodbLockWaitLongestDesc, prometheus.GaugeValue, longest,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		innodbLockWaitTimeoutsDesc, prometheus.CounterValue, innodbLockWaits.update(waits, innodbTimeout, now),
+	)
+
+	metadataRows, err := db.Query(metadataLockWaitsQuery)
+	if err != nil {
+		return err
+	}
+	defer metadataRows.Close()
+
+	var (
+		id   string
+		wait float64
+	)
+	metadataWaits := map[string]float64{}
+	for metadataRows.Next() {
+		if err := metadataRows.Scan(&id, &wait); err != nil {
+			return err
+		}
+		metadataWaits[id] = wait
+	}
+	if err := metadataRows.Err(); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		metadataLockWaitingThreadsDesc, prometheus.GaugeValue, float64(len(metadataWaits)),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		metadataLockWaitTimeoutsDesc, prometheus.CounterValue, metadataLockWaits.update(metadataWaits, metadataTimeout, now),
+	)
+	return nil
+}
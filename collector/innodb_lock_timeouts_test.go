@@ -0,0 +1,133 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const innodbLockWaitStatus = `
+------------
+TRANSACTIONS
+------------
+Trx id counter 4869
+Purge done for trx's n:o < 4860 undo n:o < 0 state: running but idle
+History list length 12
+LIST OF TRANSACTIONS FOR EACH SESSION:
+---TRANSACTION 421937253734224, not started
+0 lock struct(s), heap size 1136, 0 row lock(s)
+---TRANSACTION 4868, ACTIVE 47 sec starting index read
+mysql tables in use 1, locked 1
+LOCK WAIT 2 lock struct(s), heap size 1136, 1 row lock(s)
+MySQL thread id 9, OS thread handle 140166, query id 52 localhost root updating
+update t set a=1 where id=1
+------- TRX HAS BEEN WAITING 45 SEC FOR THIS LOCK TO BE GRANTED:
+RECORD LOCKS space id 2 page no 4 n bits 72 index PRIMARY of table ` + "`test`.`t`" + ` trx id 4868 lock_mode X locks rec but not gap waiting
+------------------
+---TRANSACTION 4867, ACTIVE 3 sec starting index read
+mysql tables in use 1, locked 1
+LOCK WAIT 2 lock struct(s), heap size 1136, 1 row lock(s)
+------- TRX HAS BEEN WAITING 2 SEC FOR THIS LOCK TO BE GRANTED:
+------------------
+---TRANSACTION 4866, ACTIVE 60 sec
+2 lock struct(s), heap size 1136, 1 row lock(s), undo log entries 1
+--------
+FILE I/O
+--------
+`
+
+func TestScrapeLockTimeouts(t *testing.T) {
+	// The scrapes run one after another, the clock is only advanced and the
+	// trackers reset while none is running.
+	now := time.Unix(1500000000, 0)
+	innodbLockWaits.reset()
+	metadataLockWaits.reset()
+	lockWaitsTimeNow = func() time.Time { return now }
+	defer func() {
+		innodbLockWaits.reset()
+		metadataLockWaits.reset()
+		lockWaitsTimeNow = time.Now
+	}()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	scrapes := []struct {
+		status        string
+		currentWaits  string
+		metadataWaits *sqlmock.Rows
+	}{
+		{
+			status:       innodbLockWaitStatus,
+			currentWaits: "2",
+			metadataWaits: sqlmock.NewRows([]string{"ID", "TIME"}).
+				AddRow("12", 25).
+				AddRow("13", 1),
+		},
+		{
+			status:        "",
+			currentWaits:  "0",
+			metadataWaits: sqlmock.NewRows([]string{"ID", "TIME"}).AddRow("13", 11),
+		},
+	}
+	for _, scrape := range scrapes {
+		mock.ExpectQuery(sanitizeQuery(lockWaitTimeoutsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"@@innodb_lock_wait_timeout", "@@lock_wait_timeout"}).AddRow(50, 30))
+		mock.ExpectQuery(sanitizeQuery(engineInnodbStatusQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"Type", "Name", "Status"}).AddRow("InnoDB", "", scrape.status))
+		mock.ExpectQuery(sanitizeQuery(innodbRowLockCurrentWaitsQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Innodb_row_lock_current_waits", scrape.currentWaits))
+		mock.ExpectQuery(sanitizeQuery(metadataLockWaitsQuery)).WillReturnRows(scrape.metadataWaits)
+	}
+
+	for i, metricsExpected := range [][]MetricResult{
+		{
+			// All the lock waits are visible.
+			{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 45, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_COUNTER},
+			{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_COUNTER},
+		},
+		{
+			// Only the waits that could have reached the timeouts are counted.
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_COUNTER},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_COUNTER},
+		},
+	} {
+		if i > 0 {
+			now = now.Add(10 * time.Second)
+		}
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err := ScrapeLockTimeouts(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		convey.Convey("Metrics comparison", t, func() {
+			for _, expect := range metricsExpected {
+				got := readMetric(<-ch)
+				convey.So(got, convey.ShouldResemble, expect)
+			}
+			_, ok := <-ch
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+	}
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
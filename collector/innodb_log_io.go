@@ -0,0 +1,79 @@
+// Scrape InnoDB redo log I/O from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const innodbLogIOQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN (
+		    'Innodb_os_log_written', 'Innodb_log_writes',
+		    'Innodb_log_write_requests', 'Innodb_os_log_fsyncs'
+		  )
+		`
+
+// Metric descriptors, rates are left to rate() on the counters.
+var (
+	innodbLogIODescs = map[string]*prometheus.Desc{
+		"innodb_os_log_written": newDesc(innodbSubsystem, "log_written_bytes_total",
+			"Total bytes written to the InnoDB redo log."),
+		"innodb_log_writes": newDesc(innodbSubsystem, "log_writes_total",
+			"Total number of physical writes to the InnoDB redo log."),
+		"innodb_log_write_requests": newDesc(innodbSubsystem, "log_write_requests_total",
+			"Total number of write requests for the InnoDB redo log."),
+		"innodb_os_log_fsyncs": newDesc(innodbSubsystem, "log_fsyncs_total",
+			"Total number of fsync() writes done to the InnoDB redo log files."),
+	}
+	innodbLogWritesPerRequestDesc = newDesc(innodbSubsystem, "log_writes_per_request_ratio",
+		"Physical InnoDB redo log writes per write request since the server started.")
+	innodbLogFsyncsPerWriteDesc = newDesc(innodbSubsystem, "log_fsyncs_per_write_ratio",
+		"InnoDB redo log fsyncs per physical write since the server started.")
+)
+
+// ScrapeInnodbLogIO collects InnoDB redo log write and fsync counters.
+func ScrapeInnodbLogIO(db *sql.DB, ch chan<- prometheus.Metric) error {
+	logIORows, err := db.Query(innodbLogIOQuery)
+	if err != nil {
+		return err
+	}
+	defer logIORows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	values := map[string]float64{}
+
+	for logIORows.Next() {
+		if err := logIORows.Scan(&key, &val); err != nil {
+			return err
+		}
+		key = strings.ToLower(key)
+		desc, ok := innodbLogIODescs[key]
+		if !ok {
+			continue
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			values[key] = floatVal
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, floatVal)
+		}
+	}
+
+	// Ratios are only meaningful once something was written.
+	if requests := values["innodb_log_write_requests"]; requests > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			innodbLogWritesPerRequestDesc, prometheus.GaugeValue, values["innodb_log_writes"]/requests,
+		)
+	}
+	if writes := values["innodb_log_writes"]; writes > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			innodbLogFsyncsPerWriteDesc, prometheus.GaugeValue, values["innodb_os_log_fsyncs"]/writes,
+		)
+	}
+	return nil
+}
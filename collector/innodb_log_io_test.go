@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbLogIO(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Innodb_log_write_requests", "4000").
+		AddRow("Innodb_log_writes", "1000").
+		AddRow("Innodb_os_log_fsyncs", "500").
+		AddRow("Innodb_os_log_written", "1048576")
+	mock.ExpectQuery(sanitizeQuery(innodbLogIOQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbLogIO(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 4000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 1000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 500, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 1048576, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.5, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeInnodbLogIONoWrites(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Innodb_log_write_requests", "0").
+		AddRow("Innodb_log_writes", "0")
+	mock.ExpectQuery(sanitizeQuery(innodbLogIOQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbLogIO(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No ratios without writes", t, func() {
+		count := 0
+		for m := range ch {
+			convey.So(readMetric(m).metricType, convey.ShouldEqual, dto.MetricType_COUNTER)
+			count++
+		}
+		convey.So(count, convey.ShouldEqual, 2)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
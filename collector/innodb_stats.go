@@ -0,0 +1,107 @@
+// Scrape the InnoDB persistent statistics settings from `SHOW GLOBAL VARIABLES`
+// and how long ago the statistics of each table were recalculated from
+// `mysql.innodb_table_stats`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	innodbStatsSettingsQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN (
+		    'innodb_stats_persistent', 'innodb_stats_auto_recalc', 'innodb_stats_persistent_sample_pages'
+		  )
+		`
+	innodbTableStatsAgeQuery = `
+		SELECT database_name, table_name, UNIX_TIMESTAMP(NOW()) - UNIX_TIMESTAMP(last_update)
+		  FROM mysql.innodb_table_stats
+		  WHERE database_name NOT IN ('mysql', 'sys')
+		  %s
+		  ORDER BY last_update
+		  LIMIT %d
+		`
+)
+
+// Tuning flags.
+var (
+	innodbTableStatsLimit = kingpin.Flag(
+		"collect.innodb_stats.limit",
+		"Limit the number of tables by the age of their statistics",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	innodbStatsSettingsDescs = map[string]*prometheus.Desc{
+		"innodb_stats_persistent": newDesc(innodbSubsystem, "stats_persistent",
+			"Whether table statistics are persisted to disk by default (1 for ON, 0 for OFF)."),
+		"innodb_stats_auto_recalc": newDesc(innodbSubsystem, "stats_auto_recalc",
+			"Whether persistent statistics are recalculated after 10% of a table changed by default (1 for ON, 0 for OFF)."),
+		"innodb_stats_persistent_sample_pages": newDesc(innodbSubsystem, "stats_persistent_sample_pages",
+			"The number of index pages sampled when calculating persistent statistics."),
+	}
+	innodbTableStatsAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "table_stats_age_seconds"),
+		"Seconds since the persistent statistics of the table were last recalculated.",
+		[]string{"schema", "table"}, nil,
+	)
+)
+
+// ScrapeInnodbStats collects the persistent statistics settings along with
+// the tables whose persistent statistics were recalculated the longest ago.
+// Only tables of the given database are collected if it is not empty.
+func ScrapeInnodbStats(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	settingRows, err := db.Query(innodbStatsSettingsQuery)
+	if err != nil {
+		return err
+	}
+	defer settingRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for settingRows.Next() {
+		if err := settingRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		desc, ok := innodbStatsSettingsDescs[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, floatVal)
+		}
+	}
+	if err := settingRows.Err(); err != nil {
+		return err
+	}
+
+	filter, args := schemaFilter(database)
+	filter = strings.Replace(filter, "TABLE_SCHEMA", "database_name", 1)
+	ageRows, err := db.Query(fmt.Sprintf(innodbTableStatsAgeQuery, filter, *innodbTableStatsLimit), args...)
+	if err != nil {
+		return err
+	}
+	defer ageRows.Close()
+
+	var (
+		schema, table string
+		age           float64
+	)
+	for ageRows.Next() {
+		if err := ageRows.Scan(&schema, &table, &age); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(innodbTableStatsAgeDesc, prometheus.GaugeValue, age, schema, table)
+	}
+	return ageRows.Err()
+}
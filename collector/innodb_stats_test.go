@@ -0,0 +1,65 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbStats(t *testing.T) {
+	limit := *innodbTableStatsLimit
+	*innodbTableStatsLimit = 5
+	defer func() { *innodbTableStatsLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("innodb_stats_auto_recalc", "ON").
+		AddRow("innodb_stats_persistent", "ON").
+		AddRow("innodb_stats_persistent_sample_pages", "20")
+	mock.ExpectQuery(sanitizeQuery(innodbStatsSettingsQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"database_name", "table_name", "age"}).
+		AddRow("shop", "archive", 2592000).
+		AddRow("shop", "orders", 300)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(innodbTableStatsAgeQuery, "AND database_name = ?", 5))).
+		WithArgs("shop").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeInnodbStats(db, ch, "shop"); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 20, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "archive"}, value: 2592000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "orders"}, value: 300, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
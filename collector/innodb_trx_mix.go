@@ -0,0 +1,61 @@
+// Scrape the read-only and read-write InnoDB transaction commits from
+// `information_schema.innodb_metrics`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const innodbTrxMixQuery = `
+		SELECT name, count
+		  FROM information_schema.innodb_metrics
+		  WHERE name IN ('trx_rw_commits', 'trx_ro_commits', 'trx_nl_ro_commits')
+		    AND status = 'enabled'
+		`
+
+// innodbTrxCommitTypes maps the innodb_metrics counters to the type of
+// transactions they count the commits of.
+var innodbTrxCommitTypes = map[string]string{
+	"trx_rw_commits":    "read_write",
+	"trx_ro_commits":    "read_only",
+	"trx_nl_ro_commits": "non_locking_read_only",
+}
+
+// Metric descriptors.
+var (
+	innodbTrxCommitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "transaction_commits_total"),
+		"Total number of transactions committed by transaction type.",
+		[]string{"type"}, nil,
+	)
+)
+
+// ScrapeInnodbTrxMix collects the commits of read-write, read-only and
+// non-locking read-only transactions. The transaction counters are disabled
+// by default, counters disabled in innodb_monitor_enable are skipped.
+func ScrapeInnodbTrxMix(db *sql.DB, ch chan<- prometheus.Metric) error {
+	trxRows, err := db.Query(innodbTrxMixQuery)
+	if err != nil {
+		return err
+	}
+	defer trxRows.Close()
+
+	var (
+		name    string
+		commits float64
+	)
+	for trxRows.Next() {
+		if err := trxRows.Scan(&name, &commits); err != nil {
+			return err
+		}
+		trxType, ok := innodbTrxCommitTypes[name]
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(innodbTrxCommitsDesc, prometheus.CounterValue, commits, trxType)
+	}
+	return trxRows.Err()
+}
@@ -0,0 +1,91 @@
+// Scrape the default transaction isolation level and the isolation levels of
+// the current sessions.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// The variable was renamed from tx_isolation in MySQL 5.7.20.
+	isolationLevelQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN ('transaction_isolation', 'tx_isolation')
+		`
+	sessionIsolationLevelsQuery = `
+		SELECT VARIABLE_VALUE, COUNT(*)
+		  FROM performance_schema.variables_by_thread
+		  WHERE VARIABLE_NAME = ?
+		  GROUP BY VARIABLE_VALUE
+		`
+)
+
+// Metric descriptors.
+var (
+	transactionIsolationInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "transaction_isolation_info"),
+		"The default transaction isolation level of the server, with a constant value of 1.",
+		[]string{"level"}, nil,
+	)
+	sessionsByIsolationLevelDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "sessions_by_isolation_level"),
+		"The number of current sessions by transaction isolation level.",
+		[]string{"level"}, nil,
+	)
+)
+
+// ScrapeIsolationLevels collects the default transaction isolation level and,
+// when performance_schema has the session variables, the number of sessions
+// using each level.
+func ScrapeIsolationLevels(db *sql.DB, ch chan<- prometheus.Metric) error {
+	levelRows, err := db.Query(isolationLevelQuery)
+	if err != nil {
+		return err
+	}
+	defer levelRows.Close()
+
+	var name, value, variable, level string
+	for levelRows.Next() {
+		if err := levelRows.Scan(&name, &value); err != nil {
+			return err
+		}
+		if variable == "" || name == "transaction_isolation" {
+			variable, level = name, value
+		}
+	}
+	if err := levelRows.Err(); err != nil {
+		return err
+	}
+	if variable == "" {
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(transactionIsolationInfoDesc, prometheus.GaugeValue, 1, level)
+
+	available, err := perfSchemaTableAvailable(db, "variables_by_thread")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.variables_by_thread is not available.")
+		return nil
+	}
+
+	sessionRows, err := db.Query(sessionIsolationLevelsQuery, variable)
+	if err != nil {
+		return err
+	}
+	defer sessionRows.Close()
+
+	var sessions uint64
+	for sessionRows.Next() {
+		if err := sessionRows.Scan(&level, &sessions); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(sessionsByIsolationLevelDesc, prometheus.GaugeValue, float64(sessions), level)
+	}
+	return sessionRows.Err()
+}
@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeIsolationLevels(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// Both names exist on 5.7.20 and later, the new one wins.
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(isolationLevelQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("transaction_isolation", "REPEATABLE-READ").
+		AddRow("tx_isolation", "REPEATABLE-READ"))
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "variables_by_thread").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(sessionIsolationLevelsQuery)).
+		WithArgs("transaction_isolation").
+		WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_VALUE", "COUNT(*)"}).
+			AddRow("READ-COMMITTED", 4).
+			AddRow("REPEATABLE-READ", 37))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeIsolationLevels(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"level": "REPEATABLE-READ"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"level": "READ-COMMITTED"}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"level": "REPEATABLE-READ"}, value: 37, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeIsolationLevelsWithoutPerfSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(isolationLevelQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("tx_isolation", "READ-COMMITTED"))
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeIsolationLevels(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Only the default level", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"level": "READ-COMMITTED"}, value: 1, metricType: dto.MetricType_GAUGE})
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
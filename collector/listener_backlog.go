@@ -0,0 +1,66 @@
+// Scrape listener errors from `SHOW GLOBAL STATUS` along with the configured
+// listen backlog.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	listener = "listener"
+	// Queries.
+	listenerBackLogQuery      = `SELECT @@back_log`
+	listenerErrorsStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Connection_errors_accept', 'Connection_errors_select', 'Connection_errors_tcpwrap')
+		`
+)
+
+// Metric descriptors.
+var (
+	listenerBackLogDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, listener, "back_log"),
+		"The number of outstanding connection requests the listener can queue from back_log.",
+		nil, nil,
+	)
+)
+
+// ScrapeListenerBacklog collects the listen backlog along with the errors
+// accepting connections, to tell whether connection storms exhaust the backlog.
+// The errors are reported as mysql_global_status_connection_errors_total, and
+// only if connectionErrors is set, as the global status collectors already
+// report them.
+func ScrapeListenerBacklog(db *sql.DB, ch chan<- prometheus.Metric, connectionErrors bool) error {
+	var backLog float64
+	if err := db.QueryRow(listenerBackLogQuery).Scan(&backLog); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(listenerBackLogDesc, prometheus.GaugeValue, backLog)
+	if !connectionErrors {
+		return nil
+	}
+
+	statusRows, err := db.Query(listenerErrorsStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		if m := globalStatusMetric(key, val); m != nil {
+			ch <- m
+		}
+	}
+	return statusRows.Err()
+}
@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeListenerBacklog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(listenerBackLogQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@back_log"}).AddRow(151))
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Connection_errors_accept", "42").
+		AddRow("Connection_errors_select", "0").
+		AddRow("Connection_errors_tcpwrap", "3")
+	mock.ExpectQuery(sanitizeQuery(listenerErrorsStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeListenerBacklog(db, ch, true); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 151, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"error": "accept"}, value: 42, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error": "select"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error": "tcpwrap"}, value: 3, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeListenerBacklogWithoutErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(listenerBackLogQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@back_log"}).AddRow(151))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeListenerBacklog(db, ch, false); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Only back_log is reported", t, func() {
+		convey.So(readMetric(<-ch), convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 151, metricType: dto.MetricType_GAUGE})
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,157 @@
+// Scrape the memory the server may use from `SHOW GLOBAL VARIABLES` and
+// `SHOW GLOBAL STATUS`, along with the global connection memory limit.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	memory = "memory"
+	// Queries.
+	memoryLimitsVariablesQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN (
+		    'innodb_buffer_pool_size',
+		    'read_buffer_size', 'read_rnd_buffer_size', 'sort_buffer_size', 'join_buffer_size',
+		    'binlog_cache_size', 'thread_stack', 'tmp_table_size',
+		    'global_connection_memory_limit', 'global_connection_memory_tracking'
+		  )
+		`
+	memoryLimitsStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Threads_connected', 'Global_connection_memory')
+		`
+)
+
+// memoryPerThreadBuffers are the buffers each connection may allocate.
+var memoryPerThreadBuffers = map[string]bool{
+	"read_buffer_size":     true,
+	"read_rnd_buffer_size": true,
+	"sort_buffer_size":     true,
+	"join_buffer_size":     true,
+	"binlog_cache_size":    true,
+	"thread_stack":         true,
+	"tmp_table_size":       true,
+}
+
+// Metric descriptors.
+var (
+	memoryBufferPoolDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, memory, "buffer_pool_bytes"),
+		"The configured size of the InnoDB buffer pool.",
+		nil, nil,
+	)
+	memoryConnectionBuffersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, memory, "connection_buffers_bytes"),
+		"The per-thread buffer sizes summed and multiplied by the connected threads, the most the connections use when all of them allocate all buffers.",
+		nil, nil,
+	)
+	globalConnectionMemoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "global_connection_memory_bytes"),
+		"The memory used by all user connections from Global_connection_memory.",
+		nil, nil,
+	)
+	globalConnectionMemoryLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "global_connection_memory_limit_bytes"),
+		"The limit of the memory used by all user connections from global_connection_memory_limit.",
+		nil, nil,
+	)
+	globalConnectionMemoryRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "global_connection_memory_ratio"),
+		"Ratio of the memory used by all user connections to global_connection_memory_limit.",
+		nil, nil,
+	)
+)
+
+// ScrapeMemoryLimits collects the memory the buffer pool and the connection
+// buffers may use, along with the global connection memory against its limit
+// from MySQL 8.0.28 while global_connection_memory_tracking is enabled.
+func ScrapeMemoryLimits(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variableRows, err := db.Query(memoryLimitsVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer variableRows.Close()
+
+	var (
+		key                    string
+		val                    sql.RawBytes
+		perThreadBuffers       float64
+		limit, tracking        float64
+		haveLimit, haveTracked bool
+	)
+	for variableRows.Next() {
+		if err := variableRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(key)
+		switch {
+		case key == "innodb_buffer_pool_size":
+			ch <- prometheus.MustNewConstMetric(memoryBufferPoolDesc, prometheus.GaugeValue, floatVal)
+		case memoryPerThreadBuffers[key]:
+			perThreadBuffers += floatVal
+		case key == "global_connection_memory_limit":
+			limit, haveLimit = floatVal, true
+		case key == "global_connection_memory_tracking":
+			tracking, haveTracked = floatVal, true
+		}
+	}
+	if err := variableRows.Err(); err != nil {
+		return err
+	}
+
+	statusRows, err := db.Query(memoryLimitsStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		threadsConnected, connectionMemory         float64
+		haveThreadsConnected, haveConnectionMemory bool
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Threads_connected":
+			threadsConnected, haveThreadsConnected = floatVal, true
+		case "Global_connection_memory":
+			connectionMemory, haveConnectionMemory = floatVal, true
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	if haveThreadsConnected {
+		ch <- prometheus.MustNewConstMetric(
+			memoryConnectionBuffersDesc, prometheus.GaugeValue, perThreadBuffers*threadsConnected,
+		)
+	}
+	// Global_connection_memory stays at 0 unless tracked.
+	if !haveLimit || !haveConnectionMemory || !haveTracked || tracking == 0 {
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(globalConnectionMemoryDesc, prometheus.GaugeValue, connectionMemory)
+	ch <- prometheus.MustNewConstMetric(globalConnectionMemoryLimitDesc, prometheus.GaugeValue, limit)
+	if limit > 0 {
+		ch <- prometheus.MustNewConstMetric(globalConnectionMemoryRatioDesc, prometheus.GaugeValue, connectionMemory/limit)
+	}
+	return nil
+}
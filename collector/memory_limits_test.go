@@ -0,0 +1,109 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeMemoryLimits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("binlog_cache_size", "32768").
+		AddRow("global_connection_memory_limit", "1073741824").
+		AddRow("global_connection_memory_tracking", "ON").
+		AddRow("innodb_buffer_pool_size", "8589934592").
+		AddRow("join_buffer_size", "262144").
+		AddRow("read_buffer_size", "131072").
+		AddRow("read_rnd_buffer_size", "262144").
+		AddRow("sort_buffer_size", "262144").
+		AddRow("thread_stack", "1048576").
+		AddRow("tmp_table_size", "16777216")
+	mock.ExpectQuery(sanitizeQuery(memoryLimitsVariablesQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows(columns).
+		AddRow("Global_connection_memory", "268435456").
+		AddRow("Threads_connected", "10")
+	mock.ExpectQuery(sanitizeQuery(memoryLimitsStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeMemoryLimits(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 8589934592, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 10 * (32768 + 262144 + 131072 + 262144 + 262144 + 1048576 + 16777216), metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 268435456, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1073741824, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeMemoryLimitsUntracked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// Before MySQL 8.0.28.
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("innodb_buffer_pool_size", "134217728").
+		AddRow("sort_buffer_size", "262144")
+	mock.ExpectQuery(sanitizeQuery(memoryLimitsVariablesQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows(columns).
+		AddRow("Threads_connected", "2")
+	mock.ExpectQuery(sanitizeQuery(memoryLimitsStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeMemoryLimits(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 134217728, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 524288, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
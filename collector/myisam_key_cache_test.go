@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeMyISAMKeyCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Key_blocks_unused", "2500").
+		AddRow("Key_blocks_used", "7500").
+		AddRow("Key_write_requests", "4000").
+		AddRow("Key_writes", "1000")
+	mock.ExpectQuery(sanitizeQuery(myisamKeyCacheStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeMyISAMKeyCache(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"state": "unused"}, value: 2500, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "used"}, value: 7500, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 4000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 1000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 0.75, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.75, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeMyISAMKeyCacheUnused(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Key_blocks_unused", "0").
+		AddRow("Key_blocks_used", "0").
+		AddRow("Key_write_requests", "0").
+		AddRow("Key_writes", "0")
+	mock.ExpectQuery(sanitizeQuery(myisamKeyCacheStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeMyISAMKeyCache(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No ratios without blocks or writes", t, func() {
+		for m := range ch {
+			convey.So(m.Desc(), convey.ShouldNotEqual, myisamKeyCacheUtilizationRatioDesc)
+			convey.So(m.Desc(), convey.ShouldNotEqual, myisamKeyCacheWriteHitRatioDesc)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
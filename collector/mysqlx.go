@@ -0,0 +1,100 @@
+// Scrape X Plugin (mysqlx) status variables.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// Subsystem.
+	mysqlx = "mysqlx"
+	// Queries.
+	mysqlxPluginQuery = `
+		SELECT COUNT(*)
+		  FROM information_schema.plugins
+		  WHERE PLUGIN_NAME = 'mysqlx' AND PLUGIN_STATUS = 'ACTIVE'
+		`
+	mysqlxStatusQuery = `SHOW GLOBAL STATUS LIKE 'Mysqlx_%'`
+)
+
+// Map known mysqlx status variables to types. Unknown variables will be
+// mapped as untyped.
+var mysqlxStatusTypes = map[string]prometheus.ValueType{
+	"bytes_received":                    prometheus.CounterValue,
+	"bytes_sent":                        prometheus.CounterValue,
+	"connection_accept_errors":          prometheus.CounterValue,
+	"connection_errors":                 prometheus.CounterValue,
+	"connections_accepted":              prometheus.CounterValue,
+	"connections_closed":                prometheus.CounterValue,
+	"connections_rejected":              prometheus.CounterValue,
+	"errors_sent":                       prometheus.CounterValue,
+	"errors_unknown_message_type":       prometheus.CounterValue,
+	"notice_other_sent":                 prometheus.CounterValue,
+	"notice_warning_sent":               prometheus.CounterValue,
+	"sessions":                          prometheus.GaugeValue,
+	"sessions_accepted":                 prometheus.CounterValue,
+	"sessions_closed":                   prometheus.CounterValue,
+	"sessions_fatal_error":              prometheus.CounterValue,
+	"sessions_killed":                   prometheus.CounterValue,
+	"sessions_rejected":                 prometheus.CounterValue,
+	"worker_threads":                    prometheus.GaugeValue,
+	"worker_threads_active":             prometheus.GaugeValue,
+	"init_error":                        prometheus.CounterValue,
+	"expect_open":                       prometheus.CounterValue,
+	"expect_close":                      prometheus.CounterValue,
+	"ssl_accepts":                       prometheus.CounterValue,
+	"ssl_finished_accepts":              prometheus.CounterValue,
+	"bytes_received_compressed_payload": prometheus.CounterValue,
+	"bytes_sent_compressed_payload":     prometheus.CounterValue,
+}
+
+// ScrapeMysqlX collects `Mysqlx_*` status variables when the X Plugin is active.
+func ScrapeMysqlX(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var plugins uint8
+	if err := db.QueryRow(mysqlxPluginQuery).Scan(&plugins); err != nil {
+		return err
+	}
+	if plugins == 0 {
+		log.Debugln("X Plugin is not active.")
+		return nil
+	}
+
+	mysqlxRows, err := db.Query(mysqlxStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer mysqlxRows.Close()
+
+	var key string
+	var val sql.RawBytes
+
+	for mysqlxRows.Next() {
+		if err := mysqlxRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok { // Unparsable values, e.g. addresses and SSL settings, are silently skipped.
+			continue
+		}
+		key = strings.TrimPrefix(strings.ToLower(key), "mysqlx_")
+		valueType, ok := mysqlxStatusTypes[key]
+		if !ok {
+			valueType = prometheus.UntypedValue
+		}
+		name := key
+		if valueType == prometheus.CounterValue {
+			name += "_total"
+		}
+		ch <- prometheus.MustNewConstMetric(
+			newDesc(mysqlx, name, "X Plugin status variable Mysqlx_"+key+"."),
+			valueType,
+			floatVal,
+		)
+	}
+	return mysqlxRows.Err()
+}
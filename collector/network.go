@@ -0,0 +1,90 @@
+// Scrape network related counters from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	network = "network"
+	// Query.
+	networkStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN (
+		    'Bytes_received', 'Bytes_sent', 'Max_used_connections',
+		    'Connection_errors_max_connections', 'Aborted_clients', 'Aborted_connects'
+		  )
+		`
+)
+
+// Metric descriptors.
+var (
+	networkBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, network, "bytes_total"),
+		"Total bytes received from and sent to all clients.",
+		[]string{"direction"}, nil,
+	)
+	networkMaxUsedConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, network, "max_used_connections"),
+		"Maximum number of connections in use simultaneously since the server started.",
+		nil, nil,
+	)
+	networkMaxConnectionsErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, network, "max_connections_errors_total"),
+		"Total number of connections refused because max_connections was reached.",
+		nil, nil,
+	)
+	networkAbortedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, network, "aborted_total"),
+		"Total number of aborted connections, by established clients or failed connection attempts.",
+		[]string{"kind"}, nil,
+	)
+)
+
+// ScrapeNetworkStats collects network related counters from `SHOW GLOBAL STATUS`.
+func ScrapeNetworkStats(db *sql.DB, ch chan<- prometheus.Metric) error {
+	networkRows, err := db.Query(networkStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer networkRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+
+	for networkRows.Next() {
+		if err := networkRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key = strings.ToLower(key); key {
+		case "bytes_received", "bytes_sent":
+			ch <- prometheus.MustNewConstMetric(
+				networkBytesDesc, prometheus.CounterValue, floatVal, strings.TrimPrefix(key, "bytes_"),
+			)
+		case "max_used_connections":
+			ch <- prometheus.MustNewConstMetric(
+				networkMaxUsedConnectionsDesc, prometheus.GaugeValue, floatVal,
+			)
+		case "connection_errors_max_connections":
+			ch <- prometheus.MustNewConstMetric(
+				networkMaxConnectionsErrorsDesc, prometheus.CounterValue, floatVal,
+			)
+		case "aborted_clients", "aborted_connects":
+			ch <- prometheus.MustNewConstMetric(
+				networkAbortedDesc, prometheus.CounterValue, floatVal, strings.TrimPrefix(key, "aborted_"),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeNetworkStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Aborted_clients", "7").
+		AddRow("Aborted_connects", "3").
+		AddRow("Bytes_received", "123456").
+		AddRow("Bytes_sent", "654321").
+		AddRow("Connection_errors_max_connections", "2").
+		AddRow("Max_used_connections", "151")
+	mock.ExpectQuery(sanitizeQuery(networkStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeNetworkStats(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"kind": "clients"}, value: 7, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"kind": "connects"}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"direction": "received"}, value: 123456, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"direction": "sent"}, value: 654321, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 2, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 151, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -1,4 +1,22 @@
 package collector
 
+import "strings"
+
 // Subsystem.
 const performanceSchema = "perf_schema"
+
+// objectSchemaFilter returns an additional WHERE condition restricting the
+// OBJECT_SCHEMA of the performance_schema tables to the given database, or else
+// to the comma separated databases, '*' for all, along with its query
+// arguments.
+func objectSchemaFilter(database, databases string) (string, []interface{}) {
+	if database != "" {
+		filter, args := databaseFilter("AND", database)
+		return strings.Replace(filter, "TABLE_SCHEMA", "OBJECT_SCHEMA", 1), args
+	}
+	if databases == "*" {
+		return "", nil
+	}
+	placeholders, args := listArgs(databases)
+	return "AND OBJECT_SCHEMA IN (" + placeholders + ")", args
+}
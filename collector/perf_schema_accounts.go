@@ -0,0 +1,83 @@
+// Scrape current and total connections by account from
+// `performance_schema.accounts`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Accounts with a NULL user belong to background threads.
+const perfAccountsQuery = `
+	SELECT USER, HOST, CURRENT_CONNECTIONS, TOTAL_CONNECTIONS
+	  FROM performance_schema.accounts
+	  WHERE USER IS NOT NULL
+	  ORDER BY CURRENT_CONNECTIONS DESC, TOTAL_CONNECTIONS DESC
+	  LIMIT %d
+	`
+
+// Tuning flags.
+var (
+	perfAccountsLimit = kingpin.Flag(
+		"collect.perf_schema.accounts.limit",
+		"Limit the number of accounts by current and total connections",
+	).Default("50").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaAccountCurrentConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "account_current_connections"),
+		"The number of current connections for the account.",
+		[]string{"user", "host"}, nil,
+	)
+	performanceSchemaAccountConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "account_connections_total"),
+		"The total number of connections for the account.",
+		[]string{"user", "host"}, nil,
+	)
+)
+
+// ScrapeAccounts collects the connections by account from
+// `performance_schema.accounts`.
+func ScrapeAccounts(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "accounts")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.accounts is not available.")
+		return nil
+	}
+
+	accountRows, err := db.Query(fmt.Sprintf(perfAccountsQuery, *perfAccountsLimit))
+	if err != nil {
+		return err
+	}
+	defer accountRows.Close()
+
+	var (
+		user           string
+		host           sql.NullString
+		current, total uint64
+	)
+	for accountRows.Next() {
+		if err := accountRows.Scan(&user, &host, &current, &total); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountCurrentConnectionsDesc, prometheus.GaugeValue, float64(current),
+			user, host.String,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountConnectionsDesc, prometheus.CounterValue, float64(total),
+			user, host.String,
+		)
+	}
+	return accountRows.Err()
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeAccounts(t *testing.T) {
+	limit := *perfAccountsLimit
+	*perfAccountsLimit = 10
+	defer func() { *perfAccountsLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "accounts").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"USER", "HOST", "CURRENT_CONNECTIONS", "TOTAL_CONNECTIONS"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "10.0.0.%", 12, 3400).
+		AddRow("root", "localhost", 1, 27).
+		AddRow("event_scheduler", nil, 1, 1)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfAccountsQuery, 10))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeAccounts(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app", "host": "10.0.0.%"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "host": "10.0.0.%"}, value: 3400, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "root", "host": "localhost"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "root", "host": "localhost"}, value: 27, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "event_scheduler", "host": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "event_scheduler", "host": ""}, value: 1, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
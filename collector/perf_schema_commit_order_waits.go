@@ -0,0 +1,90 @@
+// Scrape the time replication workers spend waiting for their turn to commit
+// with slave_preserve_commit_order from `performance_schema`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	preserveCommitOrderQuery = `SELECT @@global.slave_preserve_commit_order`
+	// Query to count the enabled and timed commit order instruments.
+	commitOrderInstrumentsQuery = `
+		SELECT COUNT(*)
+		  FROM performance_schema.setup_instruments
+		  WHERE NAME LIKE 'wait/synch/cond/sql/Commit_order_manager%'
+		    AND ENABLED = 'YES' AND TIMED = 'YES'
+		`
+	perfCommitOrderWaitsQuery = `
+		SELECT w.CHANNEL_NAME, SUM(s.COUNT_STAR), SUM(s.SUM_TIMER_WAIT)
+		  FROM performance_schema.replication_applier_status_by_worker w
+		  JOIN performance_schema.events_waits_summary_by_thread_by_event_name s
+		    ON s.THREAD_ID = w.THREAD_ID
+		  WHERE s.EVENT_NAME LIKE 'wait/synch/cond/sql/Commit_order_manager%'
+		  GROUP BY w.CHANNEL_NAME
+		`
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaCommitOrderWaitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "commit_order_waits_total"),
+		"The total number of times the workers of the channel waited for commit order.",
+		[]string{"channel_name"}, nil,
+	)
+	performanceSchemaCommitOrderWaitTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "commit_order_wait_seconds_total"),
+		"The total time the workers of the channel waited for commit order.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// ScrapeCommitOrderWaits collects the commit order waits of the replication
+// workers by channel.
+func ScrapeCommitOrderWaits(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var preserveCommitOrder bool
+	if err := db.QueryRow(preserveCommitOrderQuery).Scan(&preserveCommitOrder); err != nil {
+		return err
+	}
+	if !preserveCommitOrder {
+		log.Debugln("slave_preserve_commit_order is off.")
+		return nil
+	}
+	var instruments int
+	if err := db.QueryRow(commitOrderInstrumentsQuery).Scan(&instruments); err != nil {
+		return err
+	}
+	if instruments == 0 {
+		log.Debugln("Commit order wait instruments are not enabled.")
+		return nil
+	}
+
+	waitRows, err := db.Query(perfCommitOrderWaitsQuery)
+	if err != nil {
+		return err
+	}
+	defer waitRows.Close()
+
+	var (
+		channelName string
+		count, time uint64
+	)
+	for waitRows.Next() {
+		if err := waitRows.Scan(&channelName, &count, &time); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaCommitOrderWaitsDesc, prometheus.CounterValue, float64(count),
+			channelName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaCommitOrderWaitTimeDesc, prometheus.CounterValue, float64(time)/picoSeconds,
+			channelName,
+		)
+	}
+	return waitRows.Err()
+}
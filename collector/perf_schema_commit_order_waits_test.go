@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeCommitOrderWaits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(preserveCommitOrderQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@global.slave_preserve_commit_order"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(commitOrderInstrumentsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	columns := []string{"CHANNEL_NAME", "SUM(s.COUNT_STAR)", "SUM(s.SUM_TIMER_WAIT)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", 1200, 3500000000000).
+		AddRow("analytics", 0, 0)
+	mock.ExpectQuery(sanitizeQuery(perfCommitOrderWaitsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeCommitOrderWaits(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"channel_name": ""}, value: 1200, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"channel_name": ""}, value: 3.5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"channel_name": "analytics"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"channel_name": "analytics"}, value: 0, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeCommitOrderWaitsOff(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(preserveCommitOrderQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@global.slave_preserve_commit_order"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeCommitOrderWaits(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without preserved commit order", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Scrape the connections refused by account resource limits from
+// `performance_schema.events_errors_summary_by_account_by_error`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const perfConnectionLimitHitsQuery = `
+	SELECT USER, ERROR_NAME, SUM(SUM_ERROR_RAISED)
+	  FROM performance_schema.events_errors_summary_by_account_by_error
+	  WHERE USER IS NOT NULL
+	    AND ERROR_NAME IN ('ER_USER_LIMIT_REACHED', 'ER_TOO_MANY_USER_CONNECTIONS')
+	  GROUP BY USER, ERROR_NAME
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaConnectionLimitHitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "connection_limit_hits_total"),
+		"The number of times a user hit max_user_connections or an hourly resource limit.",
+		[]string{"user", "error_name"}, nil,
+	)
+)
+
+// ScrapeConnectionLimitHits collects the account resource limit errors raised
+// by user from `performance_schema.events_errors_summary_by_account_by_error`.
+func ScrapeConnectionLimitHits(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "events_errors_summary_by_account_by_error")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.events_errors_summary_by_account_by_error is not available.")
+		return nil
+	}
+
+	hitRows, err := db.Query(perfConnectionLimitHitsQuery)
+	if err != nil {
+		return err
+	}
+	defer hitRows.Close()
+
+	var (
+		user, errorName string
+		hits            uint64
+	)
+	for hitRows.Next() {
+		if err := hitRows.Scan(&user, &errorName, &hits); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaConnectionLimitHitsDesc, prometheus.CounterValue, float64(hits),
+			user, errorName,
+		)
+	}
+	return hitRows.Err()
+}
@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeConnectionLimitHits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "events_errors_summary_by_account_by_error").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"USER", "ERROR_NAME", "SUM(SUM_ERROR_RAISED)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "ER_TOO_MANY_USER_CONNECTIONS", 12).
+		AddRow("app", "ER_USER_LIMIT_REACHED", 0).
+		AddRow("batch", "ER_USER_LIMIT_REACHED", 3)
+	mock.ExpectQuery(sanitizeQuery(perfConnectionLimitHitsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeConnectionLimitHits(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app", "error_name": "ER_TOO_MANY_USER_CONNECTIONS"}, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "app", "error_name": "ER_USER_LIMIT_REACHED"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "batch", "error_name": "ER_USER_LIMIT_REACHED"}, value: 3, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeConnectionLimitHitsPerfSchemaDisabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeConnectionLimitHits(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without performance_schema", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
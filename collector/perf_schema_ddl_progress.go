@@ -0,0 +1,59 @@
+// Scrape online DDL progress from `performance_schema.events_stages_current`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const perfDDLProgressQuery = `
+	SELECT THREAD_ID, EVENT_NAME, WORK_COMPLETED, WORK_ESTIMATED
+	  FROM performance_schema.events_stages_current
+	  WHERE EVENT_NAME LIKE 'stage/innodb/alter table%'
+	    AND WORK_ESTIMATED > 0
+	`
+
+// Metric descriptors.
+var ddlProgressDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "ddl", "progress_ratio"),
+	"Progress of a running InnoDB ALTER TABLE, by thread and current stage.",
+	[]string{"thread_id", "stage"}, nil,
+)
+
+// ScrapeDDLProgress collects the progress of running InnoDB ALTER TABLE statements.
+func ScrapeDDLProgress(db *sql.DB, ch chan<- prometheus.Metric) error {
+	enabled, err := consumersEnabled(db, "global_instrumentation", "thread_instrumentation", "events_stages_current")
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		log.Debugln("performance_schema events_stages_current consumer is disabled.")
+		return nil
+	}
+
+	ddlProgressRows, err := db.Query(perfDDLProgressQuery)
+	if err != nil {
+		return err
+	}
+	defer ddlProgressRows.Close()
+
+	var (
+		threadID, eventName  string
+		completed, estimated float64
+	)
+
+	for ddlProgressRows.Next() {
+		if err := ddlProgressRows.Scan(&threadID, &eventName, &completed, &estimated); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			ddlProgressDesc, prometheus.GaugeValue, completed/estimated,
+			threadID, strings.TrimPrefix(eventName, "stage/innodb/"),
+		)
+	}
+	return nil
+}
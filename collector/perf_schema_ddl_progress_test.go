@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeDDLProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?,?,?"))).
+		WithArgs("global_instrumentation", "thread_instrumentation", "events_stages_current").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	columns := []string{"THREAD_ID", "EVENT_NAME", "WORK_COMPLETED", "WORK_ESTIMATED"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("48", "stage/innodb/alter table (read PK and internal sort)", "1500", "6000")
+	mock.ExpectQuery(sanitizeQuery(perfDDLProgressQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeDDLProgress(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		expect := MetricResult{labels: labelMap{"thread_id": "48", "stage": "alter table (read PK and internal sort)"}, value: 0.25, metricType: dto.MetricType_GAUGE}
+		convey.So(readMetric(<-ch), convey.ShouldResemble, expect)
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeDDLProgressConsumerDisabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?,?,?"))).
+		WithArgs("global_instrumentation", "thread_instrumentation", "events_stages_current").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeDDLProgress(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without the stage consumer", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Scrape sample statements of the slowest digests from
+// `performance_schema.events_statements_summary_by_digest`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	perfDigestSamplesQuery = `
+		SELECT DIGEST, QUERY_SAMPLE_TEXT, UNIX_TIMESTAMP(QUERY_SAMPLE_SEEN)
+		  FROM performance_schema.events_statements_summary_by_digest
+		  WHERE DIGEST IS NOT NULL AND QUERY_SAMPLE_TEXT IS NOT NULL
+		  ORDER BY SUM_TIMER_WAIT DESC
+		  LIMIT %d
+		`
+	// perfDigestSamplesMaxLimit bounds the limit flag, the sample texts
+	// make for large labels.
+	perfDigestSamplesMaxLimit = 50
+	// perfDigestSampleTextMaxLength bounds the length of the sample texts.
+	perfDigestSampleTextMaxLength = 200
+)
+
+// Tuning flags.
+var (
+	perfDigestSamplesLimit = kingpin.Flag(
+		"collect.perf_schema.digest_samples.limit",
+		"Limit the number of digests by total latency to report sample statements of, at most 50",
+	).Default("10").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaDigestSampleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "digest_sample"),
+		"A sample statement of the digest, truncated, with a constant value of 1.",
+		[]string{"digest", "sample_text"}, nil,
+	)
+	performanceSchemaDigestSampleSeenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "digest_sample_seen_timestamp_seconds"),
+		"When the sample statement of the digest was seen.",
+		[]string{"digest"}, nil,
+	)
+)
+
+// ScrapeDigestSamples collects sample statements of the digests with the
+// highest total latency.
+func ScrapeDigestSamples(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := columnExists(db, "performance_schema", "events_statements_summary_by_digest", "QUERY_SAMPLE_TEXT")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.events_statements_summary_by_digest has no sample statements.")
+		return nil
+	}
+
+	limit := *perfDigestSamplesLimit
+	if limit > perfDigestSamplesMaxLimit {
+		limit = perfDigestSamplesMaxLimit
+	}
+	samplesRows, err := db.Query(fmt.Sprintf(perfDigestSamplesQuery, limit))
+	if err != nil {
+		return err
+	}
+	defer samplesRows.Close()
+
+	var (
+		digest, sampleText string
+		seen               float64
+	)
+	for samplesRows.Next() {
+		if err := samplesRows.Scan(&digest, &sampleText, &seen); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaDigestSampleDesc, prometheus.GaugeValue, 1,
+			digest, truncateQuery(sampleText, perfDigestSampleTextMaxLength),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaDigestSampleSeenDesc, prometheus.GaugeValue, seen,
+			digest,
+		)
+	}
+	return samplesRows.Err()
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeDigestSamples(t *testing.T) {
+	limit := *perfDigestSamplesLimit
+	*perfDigestSamplesLimit = 1000
+	defer func() { *perfDigestSamplesLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(columnExistsQuery)).
+		WithArgs("performance_schema", "events_statements_summary_by_digest", "QUERY_SAMPLE_TEXT").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	longSample := "SELECT * FROM orders WHERE id IN (" + strings.Repeat("1, ", 100) + "1)"
+	columns := []string{"DIGEST", "QUERY_SAMPLE_TEXT", "QUERY_SAMPLE_SEEN"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("a1b2", "SELECT  *\n  FROM users WHERE name = 'bob'", "1500000000.5").
+		AddRow("c3d4", longSample, "1500000100")
+	// The limit is capped.
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfDigestSamplesQuery, perfDigestSamplesMaxLimit))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeDigestSamples(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"digest": "a1b2", "sample_text": "SELECT * FROM users WHERE name = 'bob'"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"digest": "a1b2"}, value: 1500000000.5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"digest": "c3d4", "sample_text": longSample[:perfDigestSampleTextMaxLength] + "..."}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"digest": "c3d4"}, value: 1500000100, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Scrape the digests creating the most on-disk temporary tables and sort
+// merge passes from `performance_schema.events_statements_summary_by_digest`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	perfDigestTmpTablesQuery = `
+		SELECT ifnull(SCHEMA_NAME, 'NONE') as SCHEMA_NAME, DIGEST, DIGEST_TEXT,
+		    SUM_CREATED_TMP_DISK_TABLES, SUM_SORT_MERGE_PASSES
+		  FROM performance_schema.events_statements_summary_by_digest
+		  WHERE ifnull(SCHEMA_NAME, '') NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+		    AND DIGEST IS NOT NULL
+		    AND (SUM_CREATED_TMP_DISK_TABLES > 0 OR SUM_SORT_MERGE_PASSES > 0)
+		  ORDER BY SUM_CREATED_TMP_DISK_TABLES DESC, SUM_SORT_MERGE_PASSES DESC
+		  LIMIT %d
+		`
+	// perfDigestTmpTablesTextMaxLength bounds the length of the digest texts.
+	perfDigestTmpTablesTextMaxLength = 120
+)
+
+// Tuning flags.
+var (
+	perfDigestTmpTablesLimit = kingpin.Flag(
+		"collect.perf_schema.digest_tmp_tables.limit",
+		"Limit the number of digests by on-disk temporary tables created",
+	).Default("10").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaDigestTmpDiskTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "digest_tmp_disk_tables_total"),
+		"The total number of on-disk temporary tables created by the digest.",
+		[]string{"schema", "digest", "digest_text"}, nil,
+	)
+	performanceSchemaDigestSortMergePassesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "digest_sort_merge_passes_total"),
+		"The total number of sort merge passes done by the digest.",
+		[]string{"schema", "digest", "digest_text"}, nil,
+	)
+)
+
+// ScrapeDigestTmpTables collects the digests of the user schemas that spill
+// the most temporary tables and sorts to disk.
+func ScrapeDigestTmpTables(db *sql.DB, ch chan<- prometheus.Metric) error {
+	digestRows, err := db.Query(fmt.Sprintf(perfDigestTmpTablesQuery, *perfDigestTmpTablesLimit))
+	if err != nil {
+		return err
+	}
+	defer digestRows.Close()
+
+	var (
+		schemaName, digest, digestText string
+		tmpDiskTables, sortMergePasses uint64
+	)
+	for digestRows.Next() {
+		if err := digestRows.Scan(&schemaName, &digest, &digestText, &tmpDiskTables, &sortMergePasses); err != nil {
+			return err
+		}
+		digestText = truncateQuery(digestText, perfDigestTmpTablesTextMaxLength)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaDigestTmpDiskTablesDesc, prometheus.CounterValue, float64(tmpDiskTables),
+			schemaName, digest, digestText,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaDigestSortMergePassesDesc, prometheus.CounterValue, float64(sortMergePasses),
+			schemaName, digest, digestText,
+		)
+	}
+	return digestRows.Err()
+}
@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeDigestTmpTables(t *testing.T) {
+	limit := *perfDigestTmpTablesLimit
+	*perfDigestTmpTablesLimit = 2
+	defer func() { *perfDigestTmpTablesLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	longText := "SELECT `a` , `b` FROM `t` GROUP BY `a` ORDER BY `b` " + strings.Repeat("LIMIT ? ", 20)
+	columns := []string{"SCHEMA_NAME", "DIGEST", "DIGEST_TEXT", "SUM_CREATED_TMP_DISK_TABLES", "SUM_SORT_MERGE_PASSES"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "d1", longText, 420, 7).
+		AddRow("app", "d2", "SELECT DISTINCT `c` FROM `u`", 0, 93)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfDigestTmpTablesQuery, 2))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeDigestTmpTables(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	truncated := truncateQuery(longText, perfDigestTmpTablesTextMaxLength)
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "app", "digest": "d1", "digest_text": truncated}, value: 420, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"schema": "app", "digest": "d1", "digest_text": truncated}, value: 7, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"schema": "app", "digest": "d2", "digest_text": "SELECT DISTINCT `c` FROM `u`"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"schema": "app", "digest": "d2", "digest_text": "SELECT DISTINCT `c` FROM `u`"}, value: 93, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		convey.So(len(truncated), convey.ShouldBeLessThan, len(longText))
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Scrape the errors raised most often from
+// `performance_schema.events_errors_summary_global_by_error`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfErrorSummaryQuery = `
+	SELECT ERROR_NUMBER, ERROR_NAME, SUM_ERROR_RAISED
+	  FROM performance_schema.events_errors_summary_global_by_error
+	  WHERE ERROR_NUMBER IS NOT NULL AND SUM_ERROR_RAISED > 0
+	  ORDER BY SUM_ERROR_RAISED DESC
+	  LIMIT %d
+	`
+
+// Tuning flags.
+var (
+	perfErrorSummaryLimit = kingpin.Flag(
+		"collect.perf_schema.error_summary.limit",
+		"Limit the number of error codes by times raised",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "errors_total"),
+		"The number of times the server raised the error.",
+		[]string{"error_number", "error_name"}, nil,
+	)
+)
+
+// ScrapeErrorSummary collects the errors raised most often from
+// `performance_schema.events_errors_summary_global_by_error`.
+func ScrapeErrorSummary(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "events_errors_summary_global_by_error")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.events_errors_summary_global_by_error is not available.")
+		return nil
+	}
+
+	errorRows, err := db.Query(fmt.Sprintf(perfErrorSummaryQuery, *perfErrorSummaryLimit))
+	if err != nil {
+		return err
+	}
+	defer errorRows.Close()
+
+	var (
+		errorNumber, errorName string
+		raised                 uint64
+	)
+	for errorRows.Next() {
+		if err := errorRows.Scan(&errorNumber, &errorName, &raised); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaErrorsDesc, prometheus.CounterValue, float64(raised),
+			errorNumber, errorName,
+		)
+	}
+	return errorRows.Err()
+}
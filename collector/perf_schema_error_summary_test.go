@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeErrorSummary(t *testing.T) {
+	limit := *perfErrorSummaryLimit
+	*perfErrorSummaryLimit = 3
+	defer func() { *perfErrorSummaryLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "events_errors_summary_global_by_error").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"ERROR_NUMBER", "ERROR_NAME", "SUM_ERROR_RAISED"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("1062", "ER_DUP_ENTRY", 250).
+		AddRow("1213", "ER_LOCK_DEADLOCK", 17).
+		AddRow("1205", "ER_LOCK_WAIT_TIMEOUT", 4)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfErrorSummaryQuery, 3))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeErrorSummary(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"error_number": "1062", "error_name": "ER_DUP_ENTRY"}, value: 250, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error_number": "1213", "error_name": "ER_LOCK_DEADLOCK"}, value: 17, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error_number": "1205", "error_name": "ER_LOCK_WAIT_TIMEOUT"}, value: 4, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -4,17 +4,36 @@ package collector
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
-const perfIndexIOWaitsQuery = `
-	SELECT OBJECT_SCHEMA, OBJECT_NAME, ifnull(INDEX_NAME, 'NONE') as INDEX_NAME,
-	    COUNT_FETCH, COUNT_INSERT, COUNT_UPDATE, COUNT_DELETE,
-	    SUM_TIMER_FETCH, SUM_TIMER_INSERT, SUM_TIMER_UPDATE, SUM_TIMER_DELETE
-	  FROM performance_schema.table_io_waits_summary_by_index_usage
-	  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema')
-	`
+const (
+	perfIndexIOWaitsQuery = `
+		SELECT OBJECT_SCHEMA, OBJECT_NAME, ifnull(INDEX_NAME, 'NONE') as INDEX_NAME,
+		    COUNT_FETCH, COUNT_INSERT, COUNT_UPDATE, COUNT_DELETE,
+		    SUM_TIMER_FETCH, SUM_TIMER_INSERT, SUM_TIMER_UPDATE, SUM_TIMER_DELETE
+		  FROM performance_schema.table_io_waits_summary_by_index_usage
+		  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema')
+		  %s
+		  %s
+		`
+	perfIndexIOWaitsLimitClause = `ORDER BY SUM_TIMER_WAIT DESC LIMIT %d`
+)
+
+// Tuning flags.
+var (
+	perfIndexIOWaitsLimit = kingpin.Flag(
+		"collect.perf_schema.indexiowaits.limit",
+		"Limit the number of indexes by total wait time, 0 for all",
+	).Default("0").Int()
+	perfIndexIOWaitsDatabases = kingpin.Flag(
+		"collect.perf_schema.indexiowaits.databases",
+		"The list of databases to collect index I/O waits for, or '*' for all",
+	).Default("*").String()
+)
 
 // Metric descriptors.
 var (
@@ -28,11 +47,23 @@ var (
 		"The total time of index I/O wait events for each index and operation.",
 		[]string{"schema", "name", "index", "operation"}, nil,
 	)
+	performanceSchemaIndexWaitsAvgTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "index_io_wait_avg_seconds"),
+		"The average time of index I/O wait events for each index and operation.",
+		[]string{"schema", "name", "index", "operation"}, nil,
+	)
 )
 
-// ScrapePerfIndexIOWaits collects for `performance_schema.table_io_waits_summary_by_index_usage`.
-func ScrapePerfIndexIOWaits(db *sql.DB, ch chan<- prometheus.Metric) error {
-	perfSchemaIndexWaitsRows, err := db.Query(perfIndexIOWaitsQuery)
+// ScrapePerfIndexIOWaits collects for `performance_schema.table_io_waits_summary_by_index_usage`,
+// only for the given database if it is not empty, or else for the databases of
+// --collect.perf_schema.indexiowaits.databases.
+func ScrapePerfIndexIOWaits(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := objectSchemaFilter(database, *perfIndexIOWaitsDatabases)
+	limit := ""
+	if *perfIndexIOWaitsLimit > 0 {
+		limit = fmt.Sprintf(perfIndexIOWaitsLimitClause, *perfIndexIOWaitsLimit)
+	}
+	perfSchemaIndexWaitsRows, err := db.Query(fmt.Sprintf(perfIndexIOWaitsQuery, filter, limit), args...)
 	if err != nil {
 		return err
 	}
@@ -90,6 +121,24 @@ func ScrapePerfIndexIOWaits(db *sql.DB, ch chan<- prometheus.Metric) error {
 			performanceSchemaIndexWaitsTimeDesc, prometheus.CounterValue, float64(timeDelete)/picoSeconds,
 			objectSchema, objectName, indexName, "delete",
 		)
+		scrapeIndexIOWaitAvg(ch, countFetch, timeFetch, objectSchema, objectName, indexName, "fetch")
+		if indexName == "NONE" {
+			scrapeIndexIOWaitAvg(ch, countInsert, timeInsert, objectSchema, objectName, indexName, "insert")
+		}
+		scrapeIndexIOWaitAvg(ch, countUpdate, timeUpdate, objectSchema, objectName, indexName, "update")
+		scrapeIndexIOWaitAvg(ch, countDelete, timeDelete, objectSchema, objectName, indexName, "delete")
 	}
 	return nil
 }
+
+// scrapeIndexIOWaitAvg reports the average wait of an operation on an index,
+// unless it never happened.
+func scrapeIndexIOWaitAvg(ch chan<- prometheus.Metric, count, time uint64, labelValues ...string) {
+	if count == 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaIndexWaitsAvgTimeDesc, prometheus.GaugeValue, float64(time)/float64(count)/picoSeconds,
+		labelValues...,
+	)
+}
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,6 +11,13 @@ import (
 )
 
 func TestScrapePerfIndexIOWaits(t *testing.T) {
+	databases := *perfIndexIOWaitsDatabases
+	*perfIndexIOWaitsDatabases = "*"
+	defer func() { *perfIndexIOWaitsDatabases = databases }()
+	limit := *perfIndexIOWaitsLimit
+	*perfIndexIOWaitsLimit = 0
+	defer func() { *perfIndexIOWaitsLimit = limit }()
+
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("error opening a stub database connection: %s", err)
@@ -21,11 +29,11 @@ func TestScrapePerfIndexIOWaits(t *testing.T) {
 		// Note, timers are in picoseconds.
 		AddRow("database", "table", "index", "10", "11", "12", "13", "14000000000000", "15000000000000", "16000000000000", "17000000000000").
 		AddRow("database", "table", "NONE", "20", "21", "22", "23", "24000000000000", "25000000000000", "26000000000000", "27000000000000")
-	mock.ExpectQuery(sanitizeQuery(perfIndexIOWaitsQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfIndexIOWaitsQuery, "", ""))).WillReturnRows(rows)
 
 	ch := make(chan prometheus.Metric)
 	go func() {
-		if err = ScrapePerfIndexIOWaits(db, ch); err != nil {
+		if err = ScrapePerfIndexIOWaits(db, ch, ""); err != nil {
 			t.Errorf("error calling function on test: %s", err)
 		}
 		close(ch)
@@ -38,6 +46,9 @@ func TestScrapePerfIndexIOWaits(t *testing.T) {
 		{labels: labelMap{"schema": "database", "name": "table", "index": "index", "operation": "fetch"}, value: 14, metricType: dto.MetricType_COUNTER},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "index", "operation": "update"}, value: 16, metricType: dto.MetricType_COUNTER},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "index", "operation": "delete"}, value: 17, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "index", "operation": "fetch"}, value: 1.4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "index", "operation": "update"}, value: 16.0 / 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "index", "operation": "delete"}, value: 17.0 / 13, metricType: dto.MetricType_GAUGE},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "fetch"}, value: 20, metricType: dto.MetricType_COUNTER},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "insert"}, value: 21, metricType: dto.MetricType_COUNTER},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "update"}, value: 22, metricType: dto.MetricType_COUNTER},
@@ -46,6 +57,10 @@ func TestScrapePerfIndexIOWaits(t *testing.T) {
 		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "insert"}, value: 25, metricType: dto.MetricType_COUNTER},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "update"}, value: 26, metricType: dto.MetricType_COUNTER},
 		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "delete"}, value: 27, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "fetch"}, value: 1.2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "insert"}, value: 25.0 / 21, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "update"}, value: 26.0 / 22, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "database", "name": "table", "index": "NONE", "operation": "delete"}, value: 27.0 / 23, metricType: dto.MetricType_GAUGE},
 	}
 	convey.Convey("Metrics comparison", t, func() {
 		for _, expect := range metricExpected {
@@ -59,3 +74,87 @@ func TestScrapePerfIndexIOWaits(t *testing.T) {
 		t.Errorf("there were unfulfilled expections: %s", err)
 	}
 }
+
+func TestScrapePerfIndexIOWaitsLimited(t *testing.T) {
+	limit := *perfIndexIOWaitsLimit
+	*perfIndexIOWaitsLimit = 1
+	defer func() { *perfIndexIOWaitsLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"OBJECT_SCHEMA", "OBJECT_NAME", "INDEX_NAME", "COUNT_FETCH", "COUNT_INSERT", "COUNT_UPDATE", "COUNT_DELETE", "SUM_TIMER_FETCH", "SUM_TIMER_INSERT", "SUM_TIMER_UPDATE", "SUM_TIMER_DELETE"}
+	rows := sqlmock.NewRows(columns).
+		// An index that was only read from.
+		AddRow("app", "orders", "idx_created", "4", "0", "0", "0", "2000000000000", "0", "0", "0")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfIndexIOWaitsQuery, "AND OBJECT_SCHEMA = ?", fmt.Sprintf(perfIndexIOWaitsLimitClause, 1)))).
+		WithArgs("app").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfIndexIOWaits(db, ch, "app"); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	var averages []MetricResult
+	for m := range ch {
+		if m.Desc() == performanceSchemaIndexWaitsAvgTimeDesc {
+			averages = append(averages, readMetric(m))
+		}
+	}
+
+	convey.Convey("Averages of the operations that happened", t, func() {
+		convey.So(averages, convey.ShouldResemble, []MetricResult{
+			{labels: labelMap{"schema": "app", "name": "orders", "index": "idx_created", "operation": "fetch"}, value: 0.5, metricType: dto.MetricType_GAUGE},
+		})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapePerfIndexIOWaitsDatabases(t *testing.T) {
+	defer func(databases, tablesDatabases string) {
+		*perfIndexIOWaitsDatabases, *tableSchemaDatabases = databases, tablesDatabases
+	}(*perfIndexIOWaitsDatabases, *tableSchemaDatabases)
+	*perfIndexIOWaitsDatabases = "app, shop"
+	// The table stats databases do not apply.
+	*tableSchemaDatabases = "other"
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"OBJECT_SCHEMA", "OBJECT_NAME", "INDEX_NAME", "COUNT_FETCH", "COUNT_INSERT", "COUNT_UPDATE", "COUNT_DELETE", "SUM_TIMER_FETCH", "SUM_TIMER_INSERT", "SUM_TIMER_UPDATE", "SUM_TIMER_DELETE"}
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfIndexIOWaitsQuery, "AND OBJECT_SCHEMA IN (?,?)", ""))).
+		WithArgs("app", "shop").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfIndexIOWaits(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Only the listed databases are collected", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
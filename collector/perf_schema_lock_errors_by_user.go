@@ -0,0 +1,76 @@
+// Scrape deadlocks and lock wait timeouts by user from
+// `performance_schema.events_errors_summary_by_account_by_error`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfLockErrorsByUserQuery = `
+	SELECT USER, ERROR_NAME, SUM(SUM_ERROR_RAISED) AS raised
+	  FROM performance_schema.events_errors_summary_by_account_by_error
+	  WHERE USER IS NOT NULL
+	    AND ERROR_NAME IN ('ER_LOCK_DEADLOCK', 'ER_LOCK_WAIT_TIMEOUT')
+	  GROUP BY USER, ERROR_NAME
+	  HAVING raised > 0
+	  ORDER BY raised DESC
+	  LIMIT %d
+	`
+
+// Tuning flags.
+var (
+	perfLockErrorsByUserLimit = kingpin.Flag(
+		"collect.perf_schema.lock_errors_by_user.limit",
+		"Limit the number of users and lock errors by times raised",
+	).Default("10").Int()
+)
+
+// Metric descriptors.
+var (
+	userLockErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "user", "lock_errors_total"),
+		"The number of deadlocks and lock wait timeouts raised for the user.",
+		[]string{"user", "error_name"}, nil,
+	)
+)
+
+// ScrapeLockErrorsByUser collects the users raising the most deadlocks and
+// lock wait timeouts from
+// `performance_schema.events_errors_summary_by_account_by_error`.
+func ScrapeLockErrorsByUser(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "events_errors_summary_by_account_by_error")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.events_errors_summary_by_account_by_error is not available.")
+		return nil
+	}
+
+	errorRows, err := db.Query(fmt.Sprintf(perfLockErrorsByUserQuery, *perfLockErrorsByUserLimit))
+	if err != nil {
+		return err
+	}
+	defer errorRows.Close()
+
+	var (
+		user, errorName string
+		raised          uint64
+	)
+	for errorRows.Next() {
+		if err := errorRows.Scan(&user, &errorName, &raised); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			userLockErrorsDesc, prometheus.CounterValue, float64(raised),
+			user, errorName,
+		)
+	}
+	return errorRows.Err()
+}
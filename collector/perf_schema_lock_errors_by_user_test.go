@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeLockErrorsByUser(t *testing.T) {
+	limit := *perfLockErrorsByUserLimit
+	*perfLockErrorsByUserLimit = 5
+	defer func() { *perfLockErrorsByUserLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "events_errors_summary_by_account_by_error").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"USER", "ERROR_NAME", "raised"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("orders", "ER_LOCK_DEADLOCK", 31).
+		AddRow("orders", "ER_LOCK_WAIT_TIMEOUT", 8).
+		AddRow("reports", "ER_LOCK_WAIT_TIMEOUT", 2)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfLockErrorsByUserQuery, 5))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeLockErrorsByUser(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "orders", "error_name": "ER_LOCK_DEADLOCK"}, value: 31, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "orders", "error_name": "ER_LOCK_WAIT_TIMEOUT"}, value: 8, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "reports", "error_name": "ER_LOCK_WAIT_TIMEOUT"}, value: 2, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Scrape the `Performance_schema_*_lost` status variables.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfSchemaLostQuery = `SHOW GLOBAL STATUS LIKE 'Performance_schema_%_lost'`
+
+// ScrapePerfSchemaLost collects the `Performance_schema_*_lost` status
+// variables as the same metrics as ScrapeGlobalStatus, for setups collecting
+// only some of the status variables. Performance schema sizes its internal
+// buffers at startup and drops instrumentation once they are full, so any
+// nonzero value means the performance_schema based metrics are incomplete and
+// the matching performance_schema_max_* or performance_schema_*_size variable
+// needs to be raised.
+func ScrapePerfSchemaLost(db *sql.DB, ch chan<- prometheus.Metric) error {
+	lostRows, err := db.Query(perfSchemaLostQuery)
+	if err != nil {
+		return err
+	}
+	defer lostRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for lostRows.Next() {
+		if err := lostRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		if metric := globalStatusMetric(key, val); metric != nil {
+			ch <- metric
+		}
+	}
+	return lostRows.Err()
+}
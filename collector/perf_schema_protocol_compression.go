@@ -0,0 +1,46 @@
+// Scrape the number of connections using protocol compression from
+// `performance_schema.status_by_thread`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const perfProtocolCompressionQuery = `
+	SELECT COUNT(*)
+	  FROM performance_schema.status_by_thread
+	  WHERE VARIABLE_NAME = 'Compression' AND VARIABLE_VALUE = 'ON'
+	`
+
+// Metric descriptors.
+var (
+	connectionsCompressedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "connections_compressed"),
+		"The number of current connections using protocol compression.",
+		nil, nil,
+	)
+)
+
+// ScrapeProtocolCompression collects the number of current connections using
+// protocol compression from `performance_schema.status_by_thread`.
+func ScrapeProtocolCompression(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := tableExists(db, "performance_schema", "status_by_thread")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.status_by_thread is not available.")
+		return nil
+	}
+
+	var compressed uint64
+	if err := db.QueryRow(perfProtocolCompressionQuery).Scan(&compressed); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(connectionsCompressedDesc, prometheus.GaugeValue, float64(compressed))
+	return nil
+}
@@ -0,0 +1,172 @@
+// Scrape `performance_schema.replication_applier_status_by_worker`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfReplicationApplierStatsByWorkerQuery = `
+	SELECT
+	    CHANNEL_NAME,
+	    WORKER_ID,
+	    ifnull(UNIX_TIMESTAMP(LAST_APPLIED_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP), 0),
+	    ifnull(UNIX_TIMESTAMP(APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP), 0),
+	    ifnull(UNIX_TIMESTAMP(APPLYING_TRANSACTION_IMMEDIATE_COMMIT_TIMESTAMP), 0),
+	    LAST_ERROR_NUMBER,
+	    LAST_ERROR_MESSAGE,
+	    ifnull(UNIX_TIMESTAMP(LAST_ERROR_TIMESTAMP), 0),
+	    UNIX_TIMESTAMP(NOW(6))
+	  FROM performance_schema.replication_applier_status_by_worker
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaReplicationApplyingOriginalCommitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "applier_worker_applying_original_commit_timestamp_seconds"),
+		"The original commit timestamp of the transaction the worker is currently applying.",
+		[]string{"channel_name", "worker_id"}, nil,
+	)
+	performanceSchemaReplicationApplyingImmediateCommitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "applier_worker_applying_immediate_commit_timestamp_seconds"),
+		"The immediate commit timestamp of the transaction the worker is currently applying.",
+		[]string{"channel_name", "worker_id"}, nil,
+	)
+	slaveApplierLagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "applier_lag_seconds"),
+		"Seconds between now and the original commit of the last transaction applied by the worker, 0 when the worker is idle.",
+		[]string{"channel_name", "worker_id"}, nil,
+	)
+	slaveWorkersBusyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "workers_busy"),
+		"Number of applier workers of the channel currently applying a transaction.",
+		[]string{"channel_name"}, nil,
+	)
+	slaveWorkersErroredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "workers_errored"),
+		"Number of applier workers of the channel whose last error is set.",
+		[]string{"channel_name"}, nil,
+	)
+	slaveWorkerLastErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "worker_last_error"),
+		"The most recent error, truncated, among the applier workers of the channel, with a constant value of 1.",
+		[]string{"channel_name", "worker_id", "errno", "error"}, nil,
+	)
+	slaveWorkersTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "workers_total"),
+		"Number of applier workers of the channel.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// ScrapePerfReplicationApplierStatsByWorker collects from `performance_schema.replication_applier_status_by_worker`.
+func ScrapePerfReplicationApplierStatsByWorker(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfReplicationApplierStatsByWorkerRows, err := db.Query(perfReplicationApplierStatsByWorkerQuery)
+	if err != nil {
+		return err
+	}
+	defer perfReplicationApplierStatsByWorkerRows.Close()
+
+	var (
+		channelName, workerID                           string
+		lastAppliedOriginal                             float64
+		applyingOriginal, applyingImmediate, serverTime float64
+		lastErrorNumber                                 uint64
+		lastErrorMessage                                string
+		lastErrorTime                                   float64
+	)
+	// Worker utilization by channel, in the order the channels were seen.
+	var channels []string
+	workersBusy := map[string]int{}
+	workersTotal := map[string]int{}
+	workersErrored := map[string]int{}
+	// The most recent error by channel.
+	type workerError struct {
+		workerID, errno, message string
+		time                     float64
+	}
+	lastErrors := map[string]workerError{}
+
+	for perfReplicationApplierStatsByWorkerRows.Next() {
+		if err := perfReplicationApplierStatsByWorkerRows.Scan(
+			&channelName, &workerID,
+			&lastAppliedOriginal, &applyingOriginal, &applyingImmediate,
+			&lastErrorNumber, &lastErrorMessage, &lastErrorTime, &serverTime,
+		); err != nil {
+			return err
+		}
+
+		if _, ok := workersTotal[channelName]; !ok {
+			channels = append(channels, channelName)
+		}
+		workersTotal[channelName]++
+		if applyingOriginal > 0 {
+			workersBusy[channelName]++
+		}
+		if lastErrorNumber != 0 {
+			workersErrored[channelName]++
+			if last, ok := lastErrors[channelName]; !ok || lastErrorTime > last.time {
+				lastErrors[channelName] = workerError{
+					workerID: workerID,
+					errno:    strconv.FormatUint(lastErrorNumber, 10),
+					message:  truncateQuery(lastErrorMessage, slaveErrorMaxLength),
+					time:     lastErrorTime,
+				}
+			}
+		}
+
+		// A zero timestamp means the worker is not applying anything.
+		if applyingOriginal > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				performanceSchemaReplicationApplyingOriginalCommitDesc, prometheus.GaugeValue, applyingOriginal,
+				channelName, workerID,
+			)
+		}
+		if applyingImmediate > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				performanceSchemaReplicationApplyingImmediateCommitDesc, prometheus.GaugeValue, applyingImmediate,
+				channelName, workerID,
+			)
+		}
+
+		// Without any applied transaction there is nothing to measure the lag against.
+		if lastAppliedOriginal == 0 {
+			continue
+		}
+		// An idle worker has applied everything it was given, so it is not lagging,
+		// no matter how long ago the last transaction was committed on the source.
+		lag := 0.0
+		if applyingOriginal > 0 && serverTime > lastAppliedOriginal {
+			lag = serverTime - lastAppliedOriginal
+		}
+		ch <- prometheus.MustNewConstMetric(
+			slaveApplierLagDesc, prometheus.GaugeValue, lag,
+			channelName, workerID,
+		)
+	}
+
+	for _, channelName := range channels {
+		ch <- prometheus.MustNewConstMetric(
+			slaveWorkersBusyDesc, prometheus.GaugeValue, float64(workersBusy[channelName]),
+			channelName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			slaveWorkersTotalDesc, prometheus.GaugeValue, float64(workersTotal[channelName]),
+			channelName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			slaveWorkersErroredDesc, prometheus.GaugeValue, float64(workersErrored[channelName]),
+			channelName,
+		)
+		if last, ok := lastErrors[channelName]; ok {
+			ch <- prometheus.MustNewConstMetric(
+				slaveWorkerLastErrorDesc, prometheus.GaugeValue, 1,
+				channelName, last.workerID, last.errno, last.message,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfReplicationApplierStatsByWorker(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{
+		"CHANNEL_NAME", "WORKER_ID",
+		"LAST_APPLIED_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP",
+		"APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP",
+		"APPLYING_TRANSACTION_IMMEDIATE_COMMIT_TIMESTAMP",
+		"LAST_ERROR_NUMBER", "LAST_ERROR_MESSAGE", "LAST_ERROR_TIMESTAMP",
+		"NOW",
+	}
+	rows := sqlmock.NewRows(columns).
+		// Busy worker.
+		AddRow("dummy_0", "1", "1500000000.5", "1500000001", "1500000002", "0", "", "0", "1500000010.5").
+		// Idle worker.
+		AddRow("dummy_0", "2", "1500000000", "0", "0", "0", "", "0", "1500000010.5").
+		// Worker that never applied anything.
+		AddRow("dummy_0", "3", "0", "0", "0", "0", "", "0", "1500000010.5").
+		// Idle worker of another channel.
+		AddRow("dummy_1", "1", "0", "0", "0", "0", "", "0", "1500000010.5")
+	mock.ExpectQuery(sanitizeQuery(perfReplicationApplierStatsByWorkerQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfReplicationApplierStatsByWorker(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"channel_name": "dummy_0", "worker_id": "1"}, value: 1500000001, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_0", "worker_id": "1"}, value: 1500000002, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_0", "worker_id": "1"}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_0", "worker_id": "2"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_0"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_0"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_0"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_1"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_1"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "dummy_1"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapePerfReplicationApplierStatsByWorkerErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{
+		"CHANNEL_NAME", "WORKER_ID",
+		"LAST_APPLIED_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP",
+		"APPLYING_TRANSACTION_ORIGINAL_COMMIT_TIMESTAMP",
+		"APPLYING_TRANSACTION_IMMEDIATE_COMMIT_TIMESTAMP",
+		"LAST_ERROR_NUMBER", "LAST_ERROR_MESSAGE", "LAST_ERROR_TIMESTAMP",
+		"NOW",
+	}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", "1", "0", "0", "0", "0", "", "0", "1500000010.5").
+		AddRow("", "2", "0", "0", "0", "1062", "Duplicate entry '1' for key 'PRIMARY'", "1500000005", "1500000010.5").
+		AddRow("", "3", "0", "0", "0", "0", "", "0", "1500000010.5")
+	mock.ExpectQuery(sanitizeQuery(perfReplicationApplierStatsByWorkerQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfReplicationApplierStatsByWorker(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"channel_name": ""}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": ""}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "worker_id": "2", "errno": "1062", "error": "Duplicate entry '1' for key 'PRIMARY'"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Scrape `performance_schema.replication_connection_status`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfReplicationConnectionStatusQuery = `
+	SELECT CHANNEL_NAME, SERVICE_STATE, COUNT_RECEIVED_HEARTBEATS
+	  FROM performance_schema.replication_connection_status
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaReplicationConnectionStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "replication_connection_service_state"),
+		"Whether the replication IO thread of the channel is running.",
+		[]string{"channel_name"}, nil,
+	)
+	performanceSchemaReplicationConnectionHeartbeatsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "replication_connection_received_heartbeats_total"),
+		"Total number of heartbeats received by the channel since the last restart or reset.",
+		[]string{"channel_name"}, nil,
+	)
+	slaveIOBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "io_bytes_total"),
+		"Bytes read from the master by the IO thread, derived from Read_Master_Log_Pos. The tail of rotated binlogs is not counted.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// slaveIOPosition is the IO thread position seen by the previous scrape.
+type slaveIOPosition struct {
+	file  string
+	pos   uint64
+	bytes float64
+}
+
+// IO thread positions by channel, kept across scrapes.
+var (
+	slaveIOPositionsMtx sync.Mutex
+	slaveIOPositions    = map[string]slaveIOPosition{}
+)
+
+// updateSlaveIOPosition accumulates the bytes read since the previous
+// position of the channel and returns the total.
+func updateSlaveIOPosition(positions map[string]slaveIOPosition, channel, file string, pos uint64) float64 {
+	prev, ok := positions[channel]
+	next := slaveIOPosition{file: file, pos: pos}
+	if ok {
+		next.bytes = prev.bytes
+		switch {
+		case file == prev.file && pos >= prev.pos:
+			next.bytes += float64(pos - prev.pos)
+		case file > prev.file:
+			// The binlog was rotated, only the part of the new file is known.
+			next.bytes += float64(pos)
+		}
+		// Otherwise the channel was reset and the position is the new baseline.
+	}
+	positions[channel] = next
+	return next.bytes
+}
+
+// ScrapePerfReplicationConnectionStatus collects from `performance_schema.replication_connection_status`.
+func ScrapePerfReplicationConnectionStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
+	connectionStatusRows, err := db.Query(perfReplicationConnectionStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer connectionStatusRows.Close()
+
+	var (
+		channelName  string
+		serviceState sql.RawBytes
+		heartbeats   uint64
+	)
+
+	for connectionStatusRows.Next() {
+		if err := connectionStatusRows.Scan(&channelName, &serviceState, &heartbeats); err != nil {
+			return err
+		}
+		if state, ok := parseStatus(serviceState); ok {
+			ch <- prometheus.MustNewConstMetric(
+				performanceSchemaReplicationConnectionStateDesc, prometheus.GaugeValue, state,
+				channelName,
+			)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaReplicationConnectionHeartbeatsDesc, prometheus.CounterValue, float64(heartbeats),
+			channelName,
+		)
+	}
+
+	return scrapeSlaveIOBytes(db, ch)
+}
+
+// scrapeSlaveIOBytes collects the bytes read by the IO thread of each channel
+// from `SHOW SLAVE STATUS`.
+func scrapeSlaveIOBytes(db *sql.DB, ch chan<- prometheus.Metric) error {
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	defer slaveStatusRows.Close()
+
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	slaveIOPositionsMtx.Lock()
+	defer slaveIOPositionsMtx.Unlock()
+
+	seen := map[string]slaveIOPosition{}
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+
+		channelName := columnValue(scanArgs, slaveCols, "Channel_Name")
+		pos, err := strconv.ParseUint(columnValue(scanArgs, slaveCols, "Read_Master_Log_Pos"), 10, 64)
+		if err != nil {
+			continue
+		}
+		if prev, ok := slaveIOPositions[channelName]; ok {
+			seen[channelName] = prev
+		}
+		bytes := updateSlaveIOPosition(seen, channelName, columnValue(scanArgs, slaveCols, "Master_Log_File"), pos)
+		ch <- prometheus.MustNewConstMetric(
+			slaveIOBytesDesc, prometheus.CounterValue, bytes,
+			channelName,
+		)
+	}
+	// Channels that were removed start from scratch if they come back.
+	slaveIOPositions = seen
+	return nil
+}
@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfReplicationConnectionStatus(t *testing.T) {
+	// The scrapes update the positions under the lock, the resets are only
+	// made while no scrape is running.
+	reset := func() {
+		slaveIOPositionsMtx.Lock()
+		defer slaveIOPositionsMtx.Unlock()
+		slaveIOPositions = map[string]slaveIOPosition{}
+	}
+	reset()
+	defer reset()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	slaveColumns := []string{"Master_Log_File", "Read_Master_Log_Pos", "Channel_Name"}
+	for _, pos := range []string{"1000", "5000"} {
+		mock.ExpectQuery(sanitizeQuery(perfReplicationConnectionStatusQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"CHANNEL_NAME", "SERVICE_STATE", "COUNT_RECEIVED_HEARTBEATS"}).
+				AddRow("", "ON", "12"))
+		mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).
+			WillReturnRows(sqlmock.NewRows(slaveColumns).AddRow("mysql-bin.000002", pos, ""))
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if err = ScrapePerfReplicationConnectionStatus(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+		}
+		close(ch)
+	}()
+
+	metricsExpected := []MetricResult{
+		{labels: labelMap{"channel_name": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": ""}, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"channel_name": ""}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"channel_name": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": ""}, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"channel_name": ""}, value: 4000, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricsExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestUpdateSlaveIOPosition(t *testing.T) {
+	positions := map[string]slaveIOPosition{}
+	convey.Convey("Bytes accumulate across rotations and resets", t, func() {
+		convey.So(updateSlaveIOPosition(positions, "", "mysql-bin.000001", 500), convey.ShouldEqual, 0)
+		convey.So(updateSlaveIOPosition(positions, "", "mysql-bin.000001", 800), convey.ShouldEqual, 300)
+		// Rotation counts the bytes read from the new file.
+		convey.So(updateSlaveIOPosition(positions, "", "mysql-bin.000002", 200), convey.ShouldEqual, 500)
+		// A reset to an earlier position is the new baseline.
+		convey.So(updateSlaveIOPosition(positions, "", "mysql-bin.000001", 4), convey.ShouldEqual, 500)
+		convey.So(updateSlaveIOPosition(positions, "", "mysql-bin.000001", 104), convey.ShouldEqual, 600)
+	})
+}
@@ -0,0 +1,111 @@
+// Scrape the stored programs taking the most time from
+// `performance_schema.events_statements_summary_by_program`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfRoutineStatsQuery = `
+	SELECT OBJECT_TYPE, OBJECT_SCHEMA, OBJECT_NAME, COUNT_STAR, SUM_TIMER_WAIT, SUM_ROWS_EXAMINED
+	  FROM performance_schema.events_statements_summary_by_program
+	  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'sys')
+	    AND COUNT_STAR > 0
+	  ORDER BY SUM_TIMER_WAIT DESC
+	  LIMIT %d
+	`
+
+// Tuning flags.
+var (
+	perfRoutineStatsLimit = kingpin.Flag(
+		"collect.perf_schema.routine_stats.limit",
+		"Limit the number of stored programs by total execution time",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaRoutineCallsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "routine_calls_total"),
+		"The total number of executions of the stored program.",
+		[]string{"object_type", "object_schema", "object_name"}, nil,
+	)
+	performanceSchemaRoutineTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "routine_seconds_total"),
+		"The total execution time of the stored program.",
+		[]string{"object_type", "object_schema", "object_name"}, nil,
+	)
+	performanceSchemaRoutineRowsExaminedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "routine_rows_examined_total"),
+		"The total number of rows examined by the statements of the stored program.",
+		[]string{"object_type", "object_schema", "object_name"}, nil,
+	)
+)
+
+// ScrapeRoutineStats collects the execution statistics of the stored
+// procedures, functions, triggers and events taking the most time, which are
+// only recorded with the global_instrumentation and thread_instrumentation
+// consumers and the statement/sp instruments enabled.
+func ScrapeRoutineStats(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "events_statements_summary_by_program")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.events_statements_summary_by_program is not available.")
+		return nil
+	}
+	for _, consumer := range []string{"global_instrumentation", "thread_instrumentation"} {
+		enabled, err := consumersEnabled(db, consumer)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			log.Debugf("performance_schema %s consumer is disabled.", consumer)
+			return nil
+		}
+	}
+	enabled, err := instrumentsEnabled(db, "statement/sp/")
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		log.Debugln("performance_schema statement/sp instruments are disabled.")
+		return nil
+	}
+
+	routineRows, err := db.Query(fmt.Sprintf(perfRoutineStatsQuery, *perfRoutineStatsLimit))
+	if err != nil {
+		return err
+	}
+	defer routineRows.Close()
+
+	var (
+		objectType, objectSchema, objectName string
+		count, time, rowsExamined            uint64
+	)
+	for routineRows.Next() {
+		if err := routineRows.Scan(&objectType, &objectSchema, &objectName, &count, &time, &rowsExamined); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaRoutineCallsDesc, prometheus.CounterValue, float64(count),
+			objectType, objectSchema, objectName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaRoutineTimeDesc, prometheus.CounterValue, float64(time)/picoSeconds,
+			objectType, objectSchema, objectName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaRoutineRowsExaminedDesc, prometheus.CounterValue, float64(rowsExamined),
+			objectType, objectSchema, objectName,
+		)
+	}
+	return routineRows.Err()
+}
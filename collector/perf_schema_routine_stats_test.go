@@ -0,0 +1,124 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeRoutineStats(t *testing.T) {
+	limit := *perfRoutineStatsLimit
+	*perfRoutineStatsLimit = 5
+	defer func() { *perfRoutineStatsLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "events_statements_summary_by_program").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+		WithArgs("global_instrumentation").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+		WithArgs("thread_instrumentation").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(instrumentsEnabledQuery)).
+		WithArgs("statement/sp/%").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(16))
+
+	columns := []string{"OBJECT_TYPE", "OBJECT_SCHEMA", "OBJECT_NAME", "COUNT_STAR", "SUM_TIMER_WAIT", "SUM_ROWS_EXAMINED"}
+	rows := sqlmock.NewRows(columns).
+		// Note, timers are in picoseconds.
+		AddRow("PROCEDURE", "shop", "close_orders", 120, 36000000000000, 5400000).
+		AddRow("FUNCTION", "shop", "price_with_tax", 90000, 1500000000000, 90000)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfRoutineStatsQuery, 5))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeRoutineStats(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	procedure := labelMap{"object_type": "PROCEDURE", "object_schema": "shop", "object_name": "close_orders"}
+	function := labelMap{"object_type": "FUNCTION", "object_schema": "shop", "object_name": "price_with_tax"}
+	metricExpected := []MetricResult{
+		{labels: procedure, value: 120, metricType: dto.MetricType_COUNTER},
+		{labels: procedure, value: 36, metricType: dto.MetricType_COUNTER},
+		{labels: procedure, value: 5400000, metricType: dto.MetricType_COUNTER},
+		{labels: function, value: 90000, metricType: dto.MetricType_COUNTER},
+		{labels: function, value: 1.5, metricType: dto.MetricType_COUNTER},
+		{labels: function, value: 90000, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeRoutineStatsDisabled(t *testing.T) {
+	convey.Convey("Nothing is collected without the prerequisites", t, func() {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+		mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+			WithArgs("performance_schema", "events_statements_summary_by_program").
+			WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+		convey.Convey("The global_instrumentation consumer is disabled", func() {
+			mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+				WithArgs("global_instrumentation").
+				WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+		})
+		convey.Convey("The statement/sp instruments are disabled", func() {
+			mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+				WithArgs("global_instrumentation").
+				WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+				WithArgs("thread_instrumentation").
+				WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+			mock.ExpectQuery(sanitizeQuery(instrumentsEnabledQuery)).
+				WithArgs("statement/sp/%").
+				WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+		})
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err = ScrapeRoutineStats(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+
+		// Ensure all SQL queries were executed
+		convey.So(mock.ExpectationsWereMet(), convey.ShouldBeNil)
+	})
+}
@@ -0,0 +1,98 @@
+// Scrape temporary table and sort usage from `performance_schema.events_statements_summary_by_account_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfSortTmpByAccountQuery = `
+	SELECT
+	    USER,
+	    SUM(SUM_CREATED_TMP_DISK_TABLES), SUM(SUM_CREATED_TMP_TABLES),
+	    SUM(SUM_SORT_MERGE_PASSES), SUM(SUM_SORT_SCAN)
+	  FROM performance_schema.events_statements_summary_by_account_by_event_name
+	  WHERE USER IS NOT NULL
+	  GROUP BY USER
+	  ORDER BY SUM(SUM_CREATED_TMP_DISK_TABLES) + SUM(SUM_SORT_MERGE_PASSES) DESC
+	  LIMIT %d
+	`
+
+// Metric descriptors.
+var (
+	perfSortTmpByAccountLimit = kingpin.Flag(
+		"collect.perf_schema.sort_tmp_by_account.limit",
+		"Maximum number of users to collect temporary table and sort usage for, by disk temporary tables and sort merge passes",
+	).Default("10").Int()
+
+	performanceSchemaAccountTmpDiskTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "account_created_tmp_disk_tables_total"),
+		"The total number of on-disk temporary tables created by statements of the user.",
+		[]string{"user"}, nil,
+	)
+	performanceSchemaAccountTmpTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "account_created_tmp_tables_total"),
+		"The total number of temporary tables created by statements of the user.",
+		[]string{"user"}, nil,
+	)
+	performanceSchemaAccountSortMergePassesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "account_sort_merge_passes_total"),
+		"The total number of sort merge passes done by statements of the user.",
+		[]string{"user"}, nil,
+	)
+	performanceSchemaAccountSortScanDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "account_sort_scan_total"),
+		"The total number of sorts done using table scans by statements of the user.",
+		[]string{"user"}, nil,
+	)
+)
+
+// ScrapeSortTmpByAccount collects temporary table and sort usage by user.
+func ScrapeSortTmpByAccount(db *sql.DB, ch chan<- prometheus.Metric) error {
+	enabled, err := consumersEnabled(db, "global_instrumentation", "thread_instrumentation", "events_statements_current")
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		log.Debugln("performance_schema statement consumers are disabled.")
+		return nil
+	}
+
+	sortTmpRows, err := db.Query(fmt.Sprintf(perfSortTmpByAccountQuery, *perfSortTmpByAccountLimit))
+	if err != nil {
+		return err
+	}
+	defer sortTmpRows.Close()
+
+	var (
+		user                       string
+		tmpDiskTables, tmpTables   uint64
+		sortMergePasses, sortScans uint64
+	)
+
+	for sortTmpRows.Next() {
+		if err := sortTmpRows.Scan(
+			&user, &tmpDiskTables, &tmpTables, &sortMergePasses, &sortScans,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountTmpDiskTablesDesc, prometheus.CounterValue, float64(tmpDiskTables), user,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountTmpTablesDesc, prometheus.CounterValue, float64(tmpTables), user,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountSortMergePassesDesc, prometheus.CounterValue, float64(sortMergePasses), user,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountSortScanDesc, prometheus.CounterValue, float64(sortScans), user,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSortTmpByAccount(t *testing.T) {
+	limit := *perfSortTmpByAccountLimit
+	*perfSortTmpByAccountLimit = 5
+	defer func() { *perfSortTmpByAccountLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?,?,?"))).
+		WithArgs("global_instrumentation", "thread_instrumentation", "events_statements_current").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	columns := []string{"USER", "tmp_disk_tables", "tmp_tables", "sort_merge_passes", "sort_scan"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "120", "400", "15", "30").
+		AddRow("report", "8", "9", "0", "2")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfSortTmpByAccountQuery, 5))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSortTmpByAccount(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app"}, value: 120, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "app"}, value: 400, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "app"}, value: 15, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "app"}, value: 30, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "report"}, value: 8, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "report"}, value: 9, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "report"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "report"}, value: 2, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Scrape the TLS ciphers negotiated by connections from
+// `performance_schema.status_by_thread`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// sslCiphersLimit bounds the number of ciphers reported, the servers
+	// only support a few.
+	sslCiphersLimit     = 50
+	perfSSLCiphersQuery = `
+		SELECT VARIABLE_VALUE, COUNT(*)
+		  FROM performance_schema.status_by_thread
+		  WHERE VARIABLE_NAME = 'Ssl_cipher'
+		  GROUP BY VARIABLE_VALUE
+		  ORDER BY COUNT(*) DESC
+		  LIMIT %d
+		`
+)
+
+// Metric descriptors.
+var (
+	connectionsBySSLCipherDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "connections_by_ssl_cipher"),
+		"The number of current connections by negotiated TLS cipher, none for unencrypted connections.",
+		[]string{"cipher"}, nil,
+	)
+)
+
+// ScrapeSSLCiphers collects the TLS ciphers of the current connections from
+// `performance_schema.status_by_thread`.
+func ScrapeSSLCiphers(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := tableExists(db, "performance_schema", "status_by_thread")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.status_by_thread is not available.")
+		return nil
+	}
+
+	cipherRows, err := db.Query(fmt.Sprintf(perfSSLCiphersQuery, sslCiphersLimit))
+	if err != nil {
+		return err
+	}
+	defer cipherRows.Close()
+
+	var (
+		cipher string
+		count  uint64
+	)
+	for cipherRows.Next() {
+		if err := cipherRows.Scan(&cipher, &count); err != nil {
+			return err
+		}
+		if cipher == "" {
+			cipher = "none"
+		}
+		ch <- prometheus.MustNewConstMetric(
+			connectionsBySSLCipherDesc, prometheus.GaugeValue, float64(count),
+			cipher,
+		)
+	}
+	return cipherRows.Err()
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSSLCiphers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "status_by_thread").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"VARIABLE_VALUE", "COUNT(*)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("TLS_AES_256_GCM_SHA384", 42).
+		AddRow("", 7).
+		AddRow("ECDHE-RSA-AES128-SHA", 2)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfSSLCiphersQuery, sslCiphersLimit))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSSLCiphers(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"cipher": "TLS_AES_256_GCM_SHA384"}, value: 42, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"cipher": "none"}, value: 7, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"cipher": "ECDHE-RSA-AES128-SHA"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
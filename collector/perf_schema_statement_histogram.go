@@ -0,0 +1,79 @@
+// Scrape the statement latency distribution from
+// `performance_schema.events_statements_histogram_global`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	perfStatementHistogramQuery = `
+		SELECT BUCKET_TIMER_HIGH, COUNT_BUCKET
+		  FROM performance_schema.events_statements_histogram_global
+		  ORDER BY BUCKET_NUMBER
+		`
+	// The histogram has no sum, the statement summary has the total latency
+	// of the same statements.
+	perfStatementLatencySumQuery = `
+		SELECT IFNULL(SUM(SUM_TIMER_WAIT), 0)
+		  FROM performance_schema.events_statements_summary_global_by_event_name
+		`
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaStatementLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "statement_latency_seconds"),
+		"The latency distribution of all statements.",
+		nil, nil,
+	)
+)
+
+// ScrapeStatementHistogram collects the statement latency histogram of MySQL 8.0
+// from `performance_schema.events_statements_histogram_global`.
+func ScrapeStatementHistogram(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "events_statements_histogram_global")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.events_statements_histogram_global is not available.")
+		return nil
+	}
+
+	histogramRows, err := db.Query(perfStatementHistogramQuery)
+	if err != nil {
+		return err
+	}
+	defer histogramRows.Close()
+
+	var (
+		timerHigh, count uint64
+		histogramCnt     uint64
+		buckets          = map[float64]uint64{}
+	)
+	for histogramRows.Next() {
+		if err := histogramRows.Scan(&timerHigh, &count); err != nil {
+			return err
+		}
+		histogramCnt += count
+		buckets[float64(timerHigh)/picoSeconds] = histogramCnt
+	}
+	if err := histogramRows.Err(); err != nil {
+		return err
+	}
+
+	var sum float64
+	if err := db.QueryRow(perfStatementLatencySumQuery).Scan(&sum); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstHistogram(
+		performanceSchemaStatementLatencyDesc, histogramCnt, sum/picoSeconds, buckets,
+	)
+	return nil
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeStatementHistogram(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "events_statements_histogram_global").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+	// Note, timers are in picoseconds.
+	columns := []string{"BUCKET_TIMER_HIGH", "COUNT_BUCKET"}
+	rows := sqlmock.NewRows(columns).
+		AddRow(1000000, 40).
+		AddRow(1000000000, 50).
+		AddRow(1000000000000, 9).
+		AddRow(10000000000000, 1)
+	mock.ExpectQuery(sanitizeQuery(perfStatementHistogramQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(perfStatementLatencySumQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"SUM"}).AddRow(12500000000000))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeStatementHistogram(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expectHistogram := prometheus.MustNewConstHistogram(performanceSchemaStatementLatencyDesc,
+		100, 12.5, map[float64]uint64{
+			1e-06: 40,
+			0.001: 90,
+			1:     99,
+			10:    100,
+		})
+	expectPb := &dto.Metric{}
+	expectHistogram.Write(expectPb)
+
+	gotPb := &dto.Metric{}
+	gotHistogram := <-ch
+	gotHistogram.Write(gotPb)
+	convey.Convey("Histogram comparison", t, func() {
+		convey.So(gotPb.Histogram, convey.ShouldResemble, expectPb.Histogram)
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
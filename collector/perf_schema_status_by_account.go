@@ -0,0 +1,70 @@
+// Scrape `performance_schema.status_by_account`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfStatusByAccountQuery = `
+	SELECT USER, HOST, VARIABLE_NAME, VARIABLE_VALUE
+	  FROM performance_schema.status_by_account
+	  WHERE USER IS NOT NULL
+	    AND VARIABLE_NAME IN (%s)
+	`
+
+// Tuning flags.
+var perfStatusByAccountVariables = kingpin.Flag(
+	"collect.perf_schema.status_by_account.variables",
+	"Comma separated list of status variables to collect per account",
+).Default("Bytes_received,Bytes_sent,Com_select,Com_insert,Com_update,Com_delete").String()
+
+// Metric descriptors.
+var performanceSchemaStatusByAccountDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, performanceSchema, "status_by_account"),
+	"Status variables aggregated by account from performance_schema.status_by_account.",
+	[]string{"user", "host", "variable"}, nil,
+)
+
+// ScrapeStatusByAccount collects from `performance_schema.status_by_account`.
+func ScrapeStatusByAccount(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := tableExists(db, "performance_schema", "status_by_account")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.status_by_account is not available.")
+		return nil
+	}
+
+	placeholders, args := listArgs(*perfStatusByAccountVariables)
+	statusByAccountRows, err := db.Query(fmt.Sprintf(perfStatusByAccountQuery, placeholders), args...)
+	if err != nil {
+		return err
+	}
+	defer statusByAccountRows.Close()
+
+	var (
+		user, host, variable string
+		value                sql.RawBytes
+	)
+
+	for statusByAccountRows.Next() {
+		if err := statusByAccountRows.Scan(&user, &host, &variable, &value); err != nil {
+			return err
+		}
+		if floatVal, ok := parseStatus(value); ok { // Unparsable values are silently skipped.
+			ch <- prometheus.MustNewConstMetric(
+				performanceSchemaStatusByAccountDesc, prometheus.UntypedValue, floatVal,
+				user, host, strings.ToLower(variable),
+			)
+		}
+	}
+	return nil
+}
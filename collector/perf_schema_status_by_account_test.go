@@ -0,0 +1,91 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeStatusByAccount(t *testing.T) {
+	variables := *perfStatusByAccountVariables
+	*perfStatusByAccountVariables = "Bytes_sent, Com_select"
+	defer func() { *perfStatusByAccountVariables = variables }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "status_by_account").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	columns := []string{"USER", "HOST", "VARIABLE_NAME", "VARIABLE_VALUE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "10.0.0.1", "Bytes_sent", "1024").
+		AddRow("app", "10.0.0.1", "Com_select", "12").
+		AddRow("report", "%", "Bytes_sent", "2048")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfStatusByAccountQuery, "?,?"))).
+		WithArgs("Bytes_sent", "Com_select").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeStatusByAccount(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app", "host": "10.0.0.1", "variable": "bytes_sent"}, value: 1024, metricType: dto.MetricType_UNTYPED},
+		{labels: labelMap{"user": "app", "host": "10.0.0.1", "variable": "com_select"}, value: 12, metricType: dto.MetricType_UNTYPED},
+		{labels: labelMap{"user": "report", "host": "%", "variable": "bytes_sent"}, value: 2048, metricType: dto.MetricType_UNTYPED},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeStatusByAccountUnavailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "status_by_account").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeStatusByAccount(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without the table", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
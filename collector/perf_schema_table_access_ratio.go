@@ -0,0 +1,68 @@
+// Scrape the share of reads in the I/O of the busiest tables from
+// `performance_schema.table_io_waits_summary_by_table`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfTableAccessRatioQuery = `
+	SELECT OBJECT_SCHEMA, OBJECT_NAME, COUNT_READ, COUNT_WRITE
+	  FROM performance_schema.table_io_waits_summary_by_table
+	  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema')
+	    AND COUNT_STAR > 0
+	  %s
+	  ORDER BY COUNT_STAR DESC
+	  LIMIT %d
+	`
+
+// Tuning flags.
+var (
+	perfTableAccessRatioLimit = kingpin.Flag(
+		"collect.perf_schema.table_access_ratio.limit",
+		"Limit the number of tables by total I/O",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	tableReadRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "read_ratio"),
+		"Ratio of reads to all I/O operations on the table.",
+		[]string{"schema", "table"}, nil,
+	)
+)
+
+// ScrapeTableAccessRatio collects the read ratio of the tables with the most
+// I/O, only for the given database if it is not empty.
+func ScrapeTableAccessRatio(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := objectSchemaFilter(database, *tableSchemaDatabases)
+	accessRows, err := db.Query(fmt.Sprintf(perfTableAccessRatioQuery, filter, *perfTableAccessRatioLimit), args...)
+	if err != nil {
+		return err
+	}
+	defer accessRows.Close()
+
+	var (
+		schema, table string
+		reads, writes uint64
+	)
+	for accessRows.Next() {
+		if err := accessRows.Scan(&schema, &table, &reads, &writes); err != nil {
+			return err
+		}
+		if reads+writes == 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			tableReadRatioDesc, prometheus.GaugeValue, float64(reads)/float64(reads+writes),
+			schema, table,
+		)
+	}
+	return accessRows.Err()
+}
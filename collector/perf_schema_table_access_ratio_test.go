@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeTableAccessRatio(t *testing.T) {
+	databases := *tableSchemaDatabases
+	*tableSchemaDatabases = "app,shop"
+	defer func() { *tableSchemaDatabases = databases }()
+	limit := *perfTableAccessRatioLimit
+	*perfTableAccessRatioLimit = 3
+	defer func() { *perfTableAccessRatioLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"OBJECT_SCHEMA", "OBJECT_NAME", "COUNT_READ", "COUNT_WRITE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "users", 9000, 1000).
+		AddRow("shop", "orders", 250, 750).
+		// Only lock waits, no reads or writes.
+		AddRow("shop", "locks", 0, 0)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfTableAccessRatioQuery, "AND OBJECT_SCHEMA IN (?,?)", 3))).
+		WithArgs("app", "shop").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTableAccessRatio(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "app", "table": "users"}, value: 0.9, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "orders"}, value: 0.25, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
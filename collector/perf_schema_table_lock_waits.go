@@ -4,11 +4,14 @@ package collector
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
-const perfTableLockWaitsQuery = `
+const (
+	perfTableLockWaitsQuery = `
 	SELECT
 	    OBJECT_SCHEMA,
 	    OBJECT_NAME,
@@ -34,7 +37,23 @@ const perfTableLockWaitsQuery = `
 	    SUM_TIMER_WRITE_EXTERNAL
 	  FROM performance_schema.table_lock_waits_summary_by_table
 	  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema')
+	  %s
+	  %s
 	`
+	perfTableLockWaitsLimitClause = `ORDER BY SUM_TIMER_WAIT DESC LIMIT %d`
+)
+
+// Tuning flags.
+var (
+	perfTableLockWaitsLimit = kingpin.Flag(
+		"collect.perf_schema.tablelocks.limit",
+		"Limit the number of tables by total lock wait time, 0 for all",
+	).Default("0").Int()
+	perfTableLockWaitsDatabases = kingpin.Flag(
+		"collect.perf_schema.tablelocks.databases",
+		"The list of databases to collect table lock waits for, or '*' for all",
+	).Default("*").String()
+)
 
 // Metric descriptors.
 var (
@@ -58,11 +77,23 @@ var (
 		"The total time of external lock wait events for each table and operation.",
 		[]string{"schema", "name", "operation"}, nil,
 	)
+	performanceSchemaTableLockWaitAvgTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "table_lock_wait_avg_seconds"),
+		"The average time of lock wait events for each table and lock type.",
+		[]string{"schema", "name", "lock_type"}, nil,
+	)
 )
 
-// ScrapePerfTableLockWaits collects from `performance_schema.table_lock_waits_summary_by_table`.
-func ScrapePerfTableLockWaits(db *sql.DB, ch chan<- prometheus.Metric) error {
-	perfSchemaTableLockWaitsRows, err := db.Query(perfTableLockWaitsQuery)
+// ScrapePerfTableLockWaits collects from `performance_schema.table_lock_waits_summary_by_table`,
+// only for the given database if it is not empty, or else for the databases of
+// --collect.perf_schema.tablelocks.databases.
+func ScrapePerfTableLockWaits(db *sql.DB, ch chan<- prometheus.Metric, database string) error {
+	filter, args := objectSchemaFilter(database, *perfTableLockWaitsDatabases)
+	limit := ""
+	if *perfTableLockWaitsLimit > 0 {
+		limit = fmt.Sprintf(perfTableLockWaitsLimitClause, *perfTableLockWaitsLimit)
+	}
+	perfSchemaTableLockWaitsRows, err := db.Query(fmt.Sprintf(perfTableLockWaitsQuery, filter, limit), args...)
 	if err != nil {
 		return err
 	}
@@ -200,6 +231,28 @@ func ScrapePerfTableLockWaits(db *sql.DB, ch chan<- prometheus.Metric) error {
 			performanceSchemaExternalTableLockWaitsTimeDesc, prometheus.CounterValue, float64(timeWriteExternal)/picoSeconds,
 			objectSchema, objectName, "write",
 		)
+		scrapeTableLockWaitAvg(ch, countReadNormal, timeReadNormal, objectSchema, objectName, "read_normal")
+		scrapeTableLockWaitAvg(ch, countReadWithSharedLocks, timeReadWithSharedLocks, objectSchema, objectName, "read_with_shared_locks")
+		scrapeTableLockWaitAvg(ch, countReadHighPriority, timeReadHighPriority, objectSchema, objectName, "read_high_priority")
+		scrapeTableLockWaitAvg(ch, countReadNoInsert, timeReadNoInsert, objectSchema, objectName, "read_no_insert")
+		scrapeTableLockWaitAvg(ch, countWriteNormal, timeWriteNormal, objectSchema, objectName, "write_normal")
+		scrapeTableLockWaitAvg(ch, countWriteAllowWrite, timeWriteAllowWrite, objectSchema, objectName, "write_allow_write")
+		scrapeTableLockWaitAvg(ch, countWriteConcurrentInsert, timeWriteConcurrentInsert, objectSchema, objectName, "write_concurrent_insert")
+		scrapeTableLockWaitAvg(ch, countWriteLowPriority, timeWriteLowPriority, objectSchema, objectName, "write_low_priority")
+		scrapeTableLockWaitAvg(ch, countReadExternal, timeReadExternal, objectSchema, objectName, "read_external")
+		scrapeTableLockWaitAvg(ch, countWriteExternal, timeWriteExternal, objectSchema, objectName, "write_external")
 	}
 	return nil
 }
+
+// scrapeTableLockWaitAvg reports the average wait of a lock type on a table,
+// unless it never happened.
+func scrapeTableLockWaitAvg(ch chan<- prometheus.Metric, count, time uint64, labelValues ...string) {
+	if count == 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaTableLockWaitAvgTimeDesc, prometheus.GaugeValue, float64(time)/float64(count)/picoSeconds,
+		labelValues...,
+	)
+}
@@ -0,0 +1,125 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfTableLockWaits(t *testing.T) {
+	limit := *perfTableLockWaitsLimit
+	*perfTableLockWaitsLimit = 5
+	defer func() { *perfTableLockWaitsLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{
+		"OBJECT_SCHEMA", "OBJECT_NAME",
+		"COUNT_READ_NORMAL", "COUNT_READ_WITH_SHARED_LOCKS", "COUNT_READ_HIGH_PRIORITY", "COUNT_READ_NO_INSERT", "COUNT_READ_EXTERNAL",
+		"COUNT_WRITE_ALLOW_WRITE", "COUNT_WRITE_CONCURRENT_INSERT", "COUNT_WRITE_LOW_PRIORITY", "COUNT_WRITE_NORMAL", "COUNT_WRITE_EXTERNAL",
+		"SUM_TIMER_READ_NORMAL", "SUM_TIMER_READ_WITH_SHARED_LOCKS", "SUM_TIMER_READ_HIGH_PRIORITY", "SUM_TIMER_READ_NO_INSERT", "SUM_TIMER_READ_EXTERNAL",
+		"SUM_TIMER_WRITE_ALLOW_WRITE", "SUM_TIMER_WRITE_CONCURRENT_INSERT", "SUM_TIMER_WRITE_LOW_PRIORITY", "SUM_TIMER_WRITE_NORMAL", "SUM_TIMER_WRITE_EXTERNAL",
+	}
+	rows := sqlmock.NewRows(columns).
+		// Note, timers are in picoseconds.
+		AddRow("shop", "orders",
+			"10", "0", "0", "0", "0",
+			"0", "0", "0", "0", "4",
+			"5000000000000", "0", "0", "0", "0",
+			"0", "0", "0", "0", "8000000000000")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfTableLockWaitsQuery, "AND OBJECT_SCHEMA = ?", "ORDER BY SUM_TIMER_WAIT DESC LIMIT 5"))).
+		WithArgs("shop").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfTableLockWaits(db, ch, "shop"); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	table := labelMap{"schema": "shop", "name": "orders"}
+	withLabel := func(name, value string) labelMap {
+		labels := labelMap{name: value}
+		for k, v := range table {
+			labels[k] = v
+		}
+		return labels
+	}
+	var metricExpected []MetricResult
+	sqlOperations := []string{
+		"read_normal", "read_with_shared_locks", "read_high_priority", "read_no_insert",
+		"write_normal", "write_allow_write", "write_concurrent_insert", "write_low_priority",
+	}
+	for _, values := range [][]float64{{10, 0, 0, 0, 0, 0, 0, 0, 0, 4}, {5, 0, 0, 0, 0, 0, 0, 0, 0, 8}} {
+		for i, operation := range sqlOperations {
+			metricExpected = append(metricExpected, MetricResult{labels: withLabel("operation", operation), value: values[i], metricType: dto.MetricType_COUNTER})
+		}
+		metricExpected = append(metricExpected,
+			MetricResult{labels: withLabel("operation", "read"), value: values[8], metricType: dto.MetricType_COUNTER},
+			MetricResult{labels: withLabel("operation", "write"), value: values[9], metricType: dto.MetricType_COUNTER},
+		)
+	}
+	metricExpected = append(metricExpected,
+		MetricResult{labels: withLabel("lock_type", "read_normal"), value: 0.5, metricType: dto.MetricType_GAUGE},
+		MetricResult{labels: withLabel("lock_type", "write_external"), value: 2, metricType: dto.MetricType_GAUGE},
+	)
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapePerfTableLockWaitsDatabases(t *testing.T) {
+	defer func(databases, tablesDatabases string) {
+		*perfTableLockWaitsDatabases, *tableSchemaDatabases = databases, tablesDatabases
+	}(*perfTableLockWaitsDatabases, *tableSchemaDatabases)
+	*perfTableLockWaitsDatabases = "*"
+	// The table stats databases do not apply.
+	*tableSchemaDatabases = "other"
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfTableLockWaitsQuery, "", ""))).
+		WillReturnRows(sqlmock.NewRows([]string{"OBJECT_SCHEMA", "OBJECT_NAME"}))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfTableLockWaits(db, ch, ""); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("All databases are collected by default", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
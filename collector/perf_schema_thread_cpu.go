@@ -0,0 +1,87 @@
+// Scrape CPU time from `performance_schema.threads`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfThreadCPUQuery = `
+	SELECT ifnull(PROCESSLIST_USER, '') AS USER, TYPE, SUM(CPU_TIME)
+	  FROM performance_schema.threads
+	  GROUP BY USER, TYPE
+	`
+
+// Tuning flags.
+var perfThreadCPUByType = kingpin.Flag(
+	"collect.perf_schema.thread_cpu.by_type",
+	"Also split thread CPU time by thread type (foreground/background)",
+).Default("false").Bool()
+
+// Metric descriptors.
+var (
+	threadCPUDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "thread", "cpu_seconds"),
+		"CPU time used by the currently running threads of each user, drops as threads exit.",
+		[]string{"user"}, nil,
+	)
+	threadCPUByTypeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "thread", "cpu_seconds"),
+		"CPU time used by the currently running threads of each user and thread type, drops as threads exit.",
+		[]string{"user", "type"}, nil,
+	)
+)
+
+// ScrapeThreadCPU collects CPU time per user from `performance_schema.threads`.
+func ScrapeThreadCPU(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := columnExists(db, "performance_schema", "threads", "CPU_TIME")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.threads.CPU_TIME is not available.")
+		return nil
+	}
+
+	threadCPURows, err := db.Query(perfThreadCPUQuery)
+	if err != nil {
+		return err
+	}
+	defer threadCPURows.Close()
+
+	var (
+		user, threadType string
+		cpuTime          float64
+		userCPUTime      = map[string]float64{}
+		users            []string
+	)
+
+	for threadCPURows.Next() {
+		if err := threadCPURows.Scan(&user, &threadType, &cpuTime); err != nil {
+			return err
+		}
+		if *perfThreadCPUByType {
+			ch <- prometheus.MustNewConstMetric(
+				threadCPUByTypeDesc, prometheus.GaugeValue, cpuTime/picoSeconds,
+				user, threadType,
+			)
+			continue
+		}
+		if _, ok := userCPUTime[user]; !ok {
+			users = append(users, user)
+		}
+		userCPUTime[user] += cpuTime
+	}
+
+	for _, user := range users {
+		ch <- prometheus.MustNewConstMetric(
+			threadCPUDesc, prometheus.GaugeValue, userCPUTime[user]/picoSeconds,
+			user,
+		)
+	}
+	return nil
+}
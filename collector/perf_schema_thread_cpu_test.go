@@ -0,0 +1,89 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeThreadCPU(t *testing.T) {
+	byType := *perfThreadCPUByType
+	*perfThreadCPUByType = false
+	defer func() { *perfThreadCPUByType = byType }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(columnExistsQuery)).
+		WithArgs("performance_schema", "threads", "CPU_TIME").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	columns := []string{"USER", "TYPE", "SUM(CPU_TIME)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", "BACKGROUND", 3e12).
+		AddRow("app", "FOREGROUND", 1.5e12).
+		AddRow("report", "FOREGROUND", 5e11).
+		AddRow("app", "BACKGROUND", 5e11)
+	mock.ExpectQuery(sanitizeQuery(perfThreadCPUQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeThreadCPU(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": ""}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "report"}, value: 0.5, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeThreadCPUUnavailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(columnExistsQuery)).
+		WithArgs("performance_schema", "threads", "CPU_TIME").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeThreadCPU(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics on older versions", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Scrape the memory used by the connection threads of each user from
+// `performance_schema.memory_summary_by_thread_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	// Query to count the enabled memory instruments.
+	memoryInstrumentsQuery = `
+		SELECT COUNT(*)
+		  FROM performance_schema.setup_instruments
+		  WHERE NAME LIKE 'memory/%' AND ENABLED = 'YES'
+		`
+	// Background threads have no user.
+	perfThreadMemoryQuery = `
+		SELECT t.PROCESSLIST_USER, SUM(m.CURRENT_NUMBER_OF_BYTES_USED) AS bytes
+		  FROM performance_schema.memory_summary_by_thread_by_event_name m
+		  JOIN performance_schema.threads t ON t.THREAD_ID = m.THREAD_ID
+		  WHERE t.PROCESSLIST_USER IS NOT NULL
+		  GROUP BY t.PROCESSLIST_USER
+		  ORDER BY bytes DESC
+		  LIMIT %d
+		`
+)
+
+// Tuning flags.
+var (
+	perfThreadMemoryLimit = kingpin.Flag(
+		"collect.perf_schema.thread_memory.limit",
+		"Limit the number of users by memory used by their threads",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	threadMemoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "thread", "memory_bytes"),
+		"The memory currently used by the threads of the user.",
+		[]string{"user"}, nil,
+	)
+)
+
+// ScrapeThreadMemory collects the memory used by the threads of the users
+// using the most memory, available from MySQL 5.7 with the memory instruments
+// enabled.
+func ScrapeThreadMemory(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "memory_summary_by_thread_by_event_name")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.memory_summary_by_thread_by_event_name is not available.")
+		return nil
+	}
+	var instruments int
+	if err := db.QueryRow(memoryInstrumentsQuery).Scan(&instruments); err != nil {
+		return err
+	}
+	if instruments == 0 {
+		log.Debugln("Memory instruments are not enabled.")
+		return nil
+	}
+
+	memoryRows, err := db.Query(fmt.Sprintf(perfThreadMemoryQuery, *perfThreadMemoryLimit))
+	if err != nil {
+		return err
+	}
+	defer memoryRows.Close()
+
+	var (
+		user  string
+		bytes float64
+	)
+	for memoryRows.Next() {
+		if err := memoryRows.Scan(&user, &bytes); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(threadMemoryDesc, prometheus.GaugeValue, bytes, user)
+	}
+	return memoryRows.Err()
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeThreadMemory(t *testing.T) {
+	limit := *perfThreadMemoryLimit
+	*perfThreadMemoryLimit = 5
+	defer func() { *perfThreadMemoryLimit = limit }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfSchemaEnabledQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@performance_schema"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "memory_summary_by_thread_by_event_name").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(memoryInstrumentsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(480))
+
+	columns := []string{"PROCESSLIST_USER", "bytes"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("reports", 268435456).
+		AddRow("app", 16777216)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfThreadMemoryQuery, 5))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeThreadMemory(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "reports"}, value: 268435456, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app"}, value: 16777216, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Scrape drift between `SHOW GLOBAL VARIABLES` and `performance_schema.persisted_variables`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const persistedVariablesQuery = `SELECT VARIABLE_NAME, VARIABLE_VALUE FROM performance_schema.persisted_variables`
+
+// Metric descriptors.
+var variableDriftDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "variable_drift"),
+	"Whether the running value of a persisted variable differs from its persisted value.",
+	[]string{"variable"}, nil,
+)
+
+// variableValuesEqual compares a running and a persisted value, treating
+// numeric and boolean spellings like ON and 1 as equal.
+func variableValuesEqual(running, persisted string) bool {
+	if strings.EqualFold(running, persisted) {
+		return true
+	}
+	runningVal, ok := parseStatus(sql.RawBytes(strings.ToUpper(running)))
+	if !ok {
+		return false
+	}
+	persistedVal, ok := parseStatus(sql.RawBytes(strings.ToUpper(persisted)))
+	return ok && runningVal == persistedVal
+}
+
+// ScrapeVariableDrift collects persisted variables whose running value differs.
+func ScrapeVariableDrift(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := tableExists(db, "performance_schema", "persisted_variables")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.persisted_variables is not available.")
+		return nil
+	}
+
+	persistedRows, err := db.Query(persistedVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer persistedRows.Close()
+
+	var key, val string
+	persisted := map[string]string{}
+
+	for persistedRows.Next() {
+		if err := persistedRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		persisted[strings.ToLower(key)] = val
+	}
+	if len(persisted) == 0 {
+		return nil
+	}
+
+	globalVariablesRows, err := db.Query(globalVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer globalVariablesRows.Close()
+
+	for globalVariablesRows.Next() {
+		if err := globalVariablesRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		key = strings.ToLower(key)
+		persistedVal, ok := persisted[key]
+		if !ok {
+			continue
+		}
+		drift := float64(1)
+		if variableValuesEqual(val, persistedVal) {
+			drift = 0
+		}
+		ch <- prometheus.MustNewConstMetric(
+			variableDriftDesc, prometheus.GaugeValue, drift, key,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Scrape `performance_schema.variables_info`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+// Variables still at their compiled-in default are left out to bound cardinality.
+const perfVariablesInfoQuery = `
+	SELECT VARIABLE_NAME, VARIABLE_SOURCE, COALESCE(SET_USER, ''), COALESCE(SET_HOST, '')
+	  FROM performance_schema.variables_info
+	  WHERE VARIABLE_SOURCE != 'COMPILED'
+	`
+
+// Metric descriptors.
+var performanceSchemaVariablesInfoDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, performanceSchema, "variables_info"),
+	"Where variables not at their compiled-in default were set from, and by whom if set at runtime.",
+	[]string{"variable", "source", "set_user", "set_host"}, nil,
+)
+
+// ScrapeVariablesInfo collects from `performance_schema.variables_info`.
+func ScrapeVariablesInfo(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := tableExists(db, "performance_schema", "variables_info")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.variables_info is not available.")
+		return nil
+	}
+
+	variablesInfoRows, err := db.Query(perfVariablesInfoQuery)
+	if err != nil {
+		return err
+	}
+	defer variablesInfoRows.Close()
+
+	var variable, source, setUser, setHost string
+
+	for variablesInfoRows.Next() {
+		if err := variablesInfoRows.Scan(&variable, &source, &setUser, &setHost); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaVariablesInfoDesc, prometheus.GaugeValue, 1,
+			strings.ToLower(variable), strings.ToLower(source), setUser, setHost,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeVariablesInfo(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("performance_schema", "variables_info").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	columns := []string{"VARIABLE_NAME", "VARIABLE_SOURCE", "SET_USER", "SET_HOST"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("datadir", "COMMAND_LINE", "", "").
+		AddRow("innodb_buffer_pool_size", "GLOBAL", "", "").
+		AddRow("max_connections", "DYNAMIC", "admin", "localhost")
+	mock.ExpectQuery(sanitizeQuery(perfVariablesInfoQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeVariablesInfo(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"variable": "datadir", "source": "command_line", "set_user": "", "set_host": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"variable": "innodb_buffer_pool_size", "source": "global", "set_user": "", "set_host": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"variable": "max_connections", "source": "dynamic", "set_user": "admin", "set_host": "localhost"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
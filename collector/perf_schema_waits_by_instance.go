@@ -0,0 +1,142 @@
+// Scrape `performance_schema.events_waits_summary_by_instance`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfWaitsByInstanceDatadirQuery = `SELECT @@datadir`
+
+const perfWaitsByInstanceQuery = `
+	SELECT w.EVENT_NAME, w.OBJECT_INSTANCE_BEGIN, COALESCE(f.FILE_NAME, ''), w.COUNT_STAR, w.SUM_TIMER_WAIT
+	  FROM performance_schema.events_waits_summary_by_instance w
+	  LEFT JOIN performance_schema.file_summary_by_instance f
+	    ON f.OBJECT_INSTANCE_BEGIN = w.OBJECT_INSTANCE_BEGIN
+	  WHERE w.EVENT_NAME LIKE ? AND w.COUNT_STAR > 0
+	  ORDER BY w.SUM_TIMER_WAIT DESC
+	  LIMIT %d
+	`
+
+// Metric descriptors.
+var (
+	perfWaitsByInstanceLimit = kingpin.Flag(
+		"collect.perf_schema.waits_by_instance.limit",
+		"Maximum number of instances to collect from performance_schema.events_waits_summary_by_instance, by total wait time",
+	).Default("20").Int()
+	perfWaitsByInstanceClass = kingpin.Flag(
+		"collect.perf_schema.waits_by_instance.class",
+		"Event name prefix of the instances to collect from performance_schema.events_waits_summary_by_instance",
+	).Default("wait/synch/mutex/").String()
+
+	performanceSchemaWaitsByInstanceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_waits_by_instance_total"),
+		"The total events waits by instance.",
+		[]string{"event_name", "instance"}, nil,
+	)
+	performanceSchemaWaitsByInstanceTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_waits_by_instance_seconds_total"),
+		"The total seconds of events waits by instance.",
+		[]string{"event_name", "instance"}, nil,
+	)
+)
+
+// perfWaitsByInstanceKey identifies the series of an instance.
+type perfWaitsByInstanceKey struct {
+	eventName, instance string
+}
+
+// ScrapePerfWaitsByInstance collects the instances with the most wait time
+// from `performance_schema.events_waits_summary_by_instance`, which needs the
+// global_instrumentation consumer and the instruments of the class enabled and
+// timed.
+func ScrapePerfWaitsByInstance(db *sql.DB, ch chan<- prometheus.Metric) error {
+	enabled, err := consumersEnabled(db, "global_instrumentation")
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		log.Debugln("performance_schema global_instrumentation consumer is disabled.")
+		return nil
+	}
+	enabled, err = instrumentsEnabled(db, *perfWaitsByInstanceClass)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		log.Debugf("No performance_schema instruments of class %s are enabled and timed.", *perfWaitsByInstanceClass)
+		return nil
+	}
+	var datadir string
+	if err := db.QueryRow(perfWaitsByInstanceDatadirQuery).Scan(&datadir); err != nil {
+		return err
+	}
+
+	// Timers here are returned in picoseconds.
+	perfWaitsByInstanceRows, err := db.Query(fmt.Sprintf(perfWaitsByInstanceQuery, *perfWaitsByInstanceLimit), *perfWaitsByInstanceClass+"%")
+	if err != nil {
+		return err
+	}
+	defer perfWaitsByInstanceRows.Close()
+
+	var (
+		eventName, fileName string
+		objectInstance      uint64
+		count, sumTimerWait uint64
+		keys                []perfWaitsByInstanceKey
+		counts              = map[perfWaitsByInstanceKey]uint64{}
+		sumTimerWaits       = map[perfWaitsByInstanceKey]uint64{}
+	)
+
+	for perfWaitsByInstanceRows.Next() {
+		if err := perfWaitsByInstanceRows.Scan(
+			&eventName, &objectInstance, &fileName, &count, &sumTimerWait,
+		); err != nil {
+			return err
+		}
+		// File instances are named after the file, others by their address.
+		key := perfWaitsByInstanceKey{eventName, fmt.Sprintf("0x%x", objectInstance)}
+		if fileName != "" {
+			key.instance = perfWaitsByInstanceFile(datadir, fileName)
+		}
+		// Instances sharing a name are summed up rather than reported twice.
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+		}
+		counts[key] += count
+		sumTimerWaits[key] += sumTimerWait
+	}
+	if err := perfWaitsByInstanceRows.Err(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaWaitsByInstanceDesc, prometheus.CounterValue, float64(counts[key]),
+			key.eventName, key.instance,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaWaitsByInstanceTimeDesc, prometheus.CounterValue, float64(sumTimerWaits[key])/picoSeconds,
+			key.eventName, key.instance,
+		)
+	}
+	return nil
+}
+
+// perfWaitsByInstanceFile returns the path of a file instance relative to the
+// data directory, files outside of it keep their full path.
+func perfWaitsByInstanceFile(datadir, fileName string) string {
+	fileName = filepath.ToSlash(fileName)
+	datadir = strings.TrimSuffix(filepath.ToSlash(datadir), "/") + "/"
+	if strings.HasPrefix(fileName, datadir) {
+		return strings.TrimPrefix(fileName, datadir)
+	}
+	return strings.TrimPrefix(fileName, "./")
+}
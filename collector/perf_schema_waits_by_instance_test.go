@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfWaitsByInstance(t *testing.T) {
+	limit, class := *perfWaitsByInstanceLimit, *perfWaitsByInstanceClass
+	*perfWaitsByInstanceLimit, *perfWaitsByInstanceClass = 2, "wait/"
+	defer func() { *perfWaitsByInstanceLimit, *perfWaitsByInstanceClass = limit, class }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+		WithArgs("global_instrumentation").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(instrumentsEnabledQuery)).
+		WithArgs("wait/%").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(12))
+	mock.ExpectQuery(sanitizeQuery(perfWaitsByInstanceDatadirQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@datadir"}).AddRow("/var/lib/mysql/"))
+
+	columns := []string{"EVENT_NAME", "OBJECT_INSTANCE_BEGIN", "FILE_NAME", "COUNT_STAR", "SUM_TIMER_WAIT"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("wait/synch/mutex/innodb/trx_sys_mutex", "140234567", "", "1000", "2000000000000").
+		AddRow("wait/io/file/innodb/innodb_data_file", "140234999", "/var/lib/mysql/db1/t.ibd", "50", "500000000000").
+		AddRow("wait/io/file/innodb/innodb_data_file", "140235999", "/var/lib/mysql/db2/t.ibd", "30", "300000000000").
+		// The same file under two instances.
+		AddRow("wait/io/file/innodb/innodb_data_file", "140236999", "/var/lib/mysql/db2/t.ibd", "10", "100000000000")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfWaitsByInstanceQuery, 2))).
+		WithArgs("wait/%").
+		WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfWaitsByInstance(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"event_name": "wait/synch/mutex/innodb/trx_sys_mutex", "instance": "0x85bcf47"}, value: 1000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": "wait/synch/mutex/innodb/trx_sys_mutex", "instance": "0x85bcf47"}, value: 2, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": "wait/io/file/innodb/innodb_data_file", "instance": "db1/t.ibd"}, value: 50, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": "wait/io/file/innodb/innodb_data_file", "instance": "db1/t.ibd"}, value: 0.5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": "wait/io/file/innodb/innodb_data_file", "instance": "db2/t.ibd"}, value: 40, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": "wait/io/file/innodb/innodb_data_file", "instance": "db2/t.ibd"}, value: 0.4, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapePerfWaitsByInstanceConsumerDisabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(consumersEnabledQuery, "?"))).
+		WithArgs("global_instrumentation").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapePerfWaitsByInstance(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without the consumer", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestPerfWaitsByInstanceFile(t *testing.T) {
+	convey.Convey("File instances are relative to the data directory", t, func() {
+		convey.So(perfWaitsByInstanceFile("/var/lib/mysql/", "/var/lib/mysql/db1/t.ibd"), convey.ShouldEqual, "db1/t.ibd")
+		convey.So(perfWaitsByInstanceFile("/var/lib/mysql", "/var/lib/mysql/ib_logfile0"), convey.ShouldEqual, "ib_logfile0")
+		convey.So(perfWaitsByInstanceFile("/var/lib/mysql/", "./undo_001"), convey.ShouldEqual, "undo_001")
+		convey.So(perfWaitsByInstanceFile("/var/lib/mysql/", "/mnt/logs/binlog.000001"), convey.ShouldEqual, "/mnt/logs/binlog.000001")
+	})
+}
@@ -0,0 +1,64 @@
+// Scrape prepared statement usage from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	preparedStmt = "prepared_stmt"
+	// Queries.
+	maxPreparedStmtCountQuery = `SELECT @@max_prepared_stmt_count`
+	preparedStmtStatusQuery   = `SHOW GLOBAL STATUS WHERE Variable_name = 'Prepared_stmt_count'`
+)
+
+// Metric descriptors.
+var (
+	preparedStmtCountRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, preparedStmt, "count_ratio"),
+		"Ratio of open prepared statements to max_prepared_stmt_count, steadily growing when clients leak statements.",
+		nil, nil,
+	)
+)
+
+// ScrapePreparedStmtCache collects how close the server is to running out of
+// prepared statements.
+func ScrapePreparedStmtCache(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var maxCount float64
+	if err := db.QueryRow(maxPreparedStmtCountQuery).Scan(&maxCount); err != nil {
+		return err
+	}
+
+	statusRows, err := db.Query(preparedStmtStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key       string
+		val       sql.RawBytes
+		count     float64
+		haveCount bool
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			count, haveCount = floatVal, true
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	if haveCount && maxCount > 0 {
+		ch <- prometheus.MustNewConstMetric(preparedStmtCountRatioDesc, prometheus.GaugeValue, count/maxCount)
+	}
+	return nil
+}
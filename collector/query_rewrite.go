@@ -0,0 +1,79 @@
+// Scrape query rewrite plugin status variables.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// Subsystem.
+	queryRewrite = "query_rewrite"
+	// Queries.
+	queryRewritePluginQuery = `
+		SELECT COUNT(*)
+		  FROM information_schema.plugins
+		  WHERE PLUGIN_NAME = 'Rewriter' AND PLUGIN_STATUS = 'ACTIVE'
+		`
+	queryRewriteStatusQuery = `SHOW GLOBAL STATUS LIKE 'Rewriter_%'`
+)
+
+// Map known query rewrite status variables to types. Unknown variables will
+// be mapped as untyped.
+var queryRewriteStatusTypes = map[string]prometheus.ValueType{
+	"number_loaded_rules":      prometheus.GaugeValue,
+	"number_reloads":           prometheus.CounterValue,
+	"number_rewritten_queries": prometheus.CounterValue,
+	"reload_error":             prometheus.GaugeValue,
+}
+
+// ScrapeQueryRewrite collects `Rewriter_*` status variables when the query
+// rewrite plugin is active.
+func ScrapeQueryRewrite(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var plugins uint8
+	if err := db.QueryRow(queryRewritePluginQuery).Scan(&plugins); err != nil {
+		return err
+	}
+	if plugins == 0 {
+		log.Debugln("Query rewrite plugin is not active.")
+		return nil
+	}
+
+	queryRewriteRows, err := db.Query(queryRewriteStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer queryRewriteRows.Close()
+
+	var key string
+	var val sql.RawBytes
+
+	for queryRewriteRows.Next() {
+		if err := queryRewriteRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok { // Unparsable values are silently skipped.
+			continue
+		}
+		key = strings.TrimPrefix(strings.ToLower(key), "rewriter_")
+		valueType, ok := queryRewriteStatusTypes[key]
+		if !ok {
+			valueType = prometheus.UntypedValue
+		}
+		name := key
+		if valueType == prometheus.CounterValue {
+			name += "_total"
+		}
+		ch <- prometheus.MustNewConstMetric(
+			newDesc(queryRewrite, name, "Query rewrite plugin status variable Rewriter_"+key+"."),
+			valueType,
+			floatVal,
+		)
+	}
+	return queryRewriteRows.Err()
+}
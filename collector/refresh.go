@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+// refreshStaleIntervals is the number of refresh intervals after which the
+// cached metrics of a collector are considered stale and no longer served.
+const refreshStaleIntervals = 2
+
+var (
+	// refreshers holds the background refreshes by collector name. They
+	// outlive the exporter of a single scrape, which is created per request.
+	refreshers    = map[string]*refresher{}
+	refreshersMtx = &sync.Mutex{}
+
+	// refreshTicker and refreshNow are the clock of the refreshes, replaced
+	// in tests.
+	refreshTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		t := time.NewTicker(d)
+		return t.C, t.Stop
+	}
+	refreshNow = time.Now
+)
+
+// refresher runs a collector in the background and caches its metrics.
+type refresher struct {
+	name     string
+	interval time.Duration
+	scrape   func(chan<- prometheus.Metric) error
+	stopCh   chan struct{}
+	// readyCh is closed once the first refresh is done.
+	readyCh chan struct{}
+
+	mtx       sync.Mutex
+	metrics   []prometheus.Metric
+	err       error
+	refreshed time.Time
+}
+
+// scrapeRefreshed serves the cached metrics of the named collector, starting
+// its background refresh every interval on first use.
+func scrapeRefreshed(name string, interval time.Duration, ch chan<- prometheus.Metric, scrape func(chan<- prometheus.Metric) error) error {
+	refreshersMtx.Lock()
+	r, ok := refreshers[name]
+	if !ok {
+		r = &refresher{
+			name:     name,
+			interval: interval,
+			scrape:   scrape,
+			stopCh:   make(chan struct{}),
+			readyCh:  make(chan struct{}),
+		}
+		refreshers[name] = r
+	}
+	refreshersMtx.Unlock()
+
+	if !ok {
+		// The first refresh is synchronous so that there is something
+		// to serve, other scrapes of the collector wait for it.
+		r.refresh()
+		close(r.readyCh)
+		tickCh, stop := refreshTicker(r.interval)
+		go r.run(tickCh, stop)
+	}
+	<-r.readyCh
+	return r.serve(ch)
+}
+
+// stopRefresher stops the background refresh of the named collector, if any.
+func stopRefresher(name string) {
+	refreshersMtx.Lock()
+	defer refreshersMtx.Unlock()
+	if r, ok := refreshers[name]; ok {
+		close(r.stopCh)
+		delete(refreshers, name)
+	}
+}
+
+// run refreshes on every tick until the refresher is stopped.
+func (r *refresher) run(tickCh <-chan time.Time, stop func()) {
+	defer stop()
+	for {
+		select {
+		case <-tickCh:
+			r.refresh()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// refresh runs the collector, replacing the cached metrics if it succeeds.
+func (r *refresher) refresh() {
+	metrics, err := bufferMetrics(r.scrape)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.err = err
+	if err != nil {
+		log.Errorln("Error refreshing "+r.name+":", err)
+		return
+	}
+	r.metrics = metrics
+	r.refreshed = refreshNow()
+}
+
+// serve sends the cached metrics on unless they are stale, returning the
+// error of the last refresh.
+func (r *refresher) serve(ch chan<- prometheus.Metric) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.refreshed.IsZero() {
+		return r.err
+	}
+	if age := refreshNow().Sub(r.refreshed); age > refreshStaleIntervals*r.interval {
+		return fmt.Errorf("metrics of %s are stale, last refreshed %s ago", r.name, age)
+	}
+	for _, m := range r.metrics {
+		ch <- m
+	}
+	return r.err
+}
@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestScrapeRefreshed(t *testing.T) {
+	// Replace the clock with one advanced by the test.
+	now := time.Unix(1500000000, 0)
+	tickCh := make(chan time.Time)
+	defer func(ticker func(time.Duration) (<-chan time.Time, func()), timeNow func() time.Time) {
+		refreshTicker, refreshNow = ticker, timeNow
+	}(refreshTicker, refreshNow)
+	refreshTicker = func(time.Duration) (<-chan time.Time, func()) { return tickCh, func() {} }
+	refreshNow = func() time.Time { return now }
+
+	desc := newDesc("test", "refreshed", "Test metric.")
+	refreshes := 0
+	refreshedCh := make(chan struct{}, 1)
+	scrape := func(ch chan<- prometheus.Metric) error {
+		refreshes++
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(refreshes))
+		refreshedCh <- struct{}{}
+		return nil
+	}
+	serve := func() ([]MetricResult, error) {
+		ch := make(chan prometheus.Metric)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- scrapeRefreshed("collect.test", time.Minute, ch, scrape)
+			close(ch)
+		}()
+		var results []MetricResult
+		for m := range ch {
+			results = append(results, readMetric(m))
+		}
+		return results, <-errCh
+	}
+	defer stopRefresher("collect.test")
+
+	convey.Convey("Background refresh cadence", t, func() {
+		// The first scrape refreshes synchronously.
+		results, err := serve()
+		<-refreshedCh
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(results, convey.ShouldResemble, []MetricResult{{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE}})
+
+		// Further scrapes are served from the cache until the next tick.
+		now = now.Add(30 * time.Second)
+		results, err = serve()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(results, convey.ShouldResemble, []MetricResult{{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE}})
+
+		now = now.Add(30 * time.Second)
+		tickCh <- now
+		<-refreshedCh
+		// Wait for the refresh to store its metrics.
+		for i := 0; i < 100; i++ {
+			if results, err = serve(); len(results) == 1 && results[0].value == 2 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(results, convey.ShouldResemble, []MetricResult{{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE}})
+
+		// Without refreshes the cache goes stale.
+		now = now.Add(refreshStaleIntervals*time.Minute + time.Second)
+		results, err = serve()
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(results, convey.ShouldBeEmpty)
+	})
+}
+
+func TestScrapeRefreshedConcurrentFirstRefresh(t *testing.T) {
+	blockCh := make(chan struct{})
+	slow := func(ch chan<- prometheus.Metric) error {
+		<-blockCh
+		return nil
+	}
+	fast := func(ch chan<- prometheus.Metric) error { return nil }
+	defer stopRefresher("collect.slow")
+	defer stopRefresher("collect.fast")
+
+	slowDone := make(chan error, 1)
+	go func() {
+		slowDone <- scrapeRefreshed("collect.slow", time.Hour, make(chan prometheus.Metric), slow)
+	}()
+
+	// Wait for the slow refresher to be registered.
+	for registered := false; !registered; time.Sleep(time.Millisecond) {
+		refreshersMtx.Lock()
+		_, registered = refreshers["collect.slow"]
+		refreshersMtx.Unlock()
+	}
+
+	convey.Convey("A slow first refresh does not block other collectors", t, func() {
+		fastDone := make(chan error, 1)
+		go func() {
+			fastDone <- scrapeRefreshed("collect.fast", time.Hour, make(chan prometheus.Metric), fast)
+		}()
+		select {
+		case err := <-fastDone:
+			convey.So(err, convey.ShouldBeNil)
+		case <-time.After(5 * time.Second):
+			t.Fatal("first refresh of collect.fast blocked by collect.slow")
+		}
+		close(blockCh)
+		convey.So(<-slowDone, convey.ShouldBeNil)
+	})
+}
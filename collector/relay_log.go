@@ -0,0 +1,108 @@
+// Scrape relay log space usage and limits.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const relayLogVariablesQuery = `SELECT @@relay_log_space_limit, @@relay_log_purge`
+
+// Metric descriptors.
+var (
+	relayLogSpaceLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "relay_log_space_limit_bytes"),
+		"The configured @@relay_log_space_limit, 0 means unlimited.",
+		nil, nil,
+	)
+	relayLogPurgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "relay_log_purge"),
+		"Whether @@relay_log_purge is enabled.",
+		nil, nil,
+	)
+	relayLogSpaceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "relay_log_space_bytes"),
+		"Total combined size of all existing relay log files.",
+		[]string{"channel_name", "connection_name"}, nil,
+	)
+	relayLogSpaceHeadroomDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "relay_log_space_headroom_bytes"),
+		"Bytes left before @@relay_log_space_limit is reached, only exposed when a limit is set.",
+		[]string{"channel_name", "connection_name"}, nil,
+	)
+)
+
+// ScrapeRelayLog collects relay log space usage from `SHOW SLAVE STATUS`.
+func ScrapeRelayLog(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var spaceLimit uint64
+	var purge bool
+	if err := db.QueryRow(relayLogVariablesQuery).Scan(&spaceLimit, &purge); err != nil {
+		return err
+	}
+
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	defer slaveStatusRows.Close()
+
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	replica := false
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+		replica = true
+
+		channelName := columnValue(scanArgs, slaveCols, "Channel_Name")       // MySQL & Percona
+		connectionName := columnValue(scanArgs, slaveCols, "Connection_name") // MariaDB
+
+		space, err := strconv.ParseUint(columnValue(scanArgs, slaveCols, "Relay_Log_Space"), 10, 64)
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			relayLogSpaceDesc, prometheus.GaugeValue, float64(space),
+			channelName, connectionName,
+		)
+		if spaceLimit > 0 {
+			headroom := float64(0)
+			if space < spaceLimit {
+				headroom = float64(spaceLimit - space)
+			}
+			ch <- prometheus.MustNewConstMetric(
+				relayLogSpaceHeadroomDesc, prometheus.GaugeValue, headroom,
+				channelName, connectionName,
+			)
+		}
+	}
+
+	// Relay logs are not in use if the server is not a replica.
+	if !replica {
+		return nil
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		relayLogSpaceLimitDesc, prometheus.GaugeValue, float64(spaceLimit),
+	)
+	purgeVal := float64(0)
+	if purge {
+		purgeVal = 1
+	}
+	ch <- prometheus.MustNewConstMetric(
+		relayLogPurgeDesc, prometheus.GaugeValue, purgeVal,
+	)
+	return nil
+}
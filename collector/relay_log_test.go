@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeRelayLog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(relayLogVariablesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@relay_log_space_limit", "@@relay_log_purge"}).AddRow(4096, 1))
+
+	columns := []string{"Master_Host", "Relay_Log_File", "Relay_Log_Space", "Channel_Name"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("127.0.0.1", "relay-bin.000012", "1024", "").
+		AddRow("127.0.0.2", "relay-bin-c2.000003", "5000", "c2")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeRelayLog(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	counterExpected := []MetricResult{
+		{labels: labelMap{"channel_name": "", "connection_name": ""}, value: 1024, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "connection_name": ""}, value: 3072, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "c2", "connection_name": ""}, value: 5000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "c2", "connection_name": ""}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 4096, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range counterExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeRelayLogNotReplica(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(relayLogVariablesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@relay_log_space_limit", "@@relay_log_purge"}).AddRow(0, 1))
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(sqlmock.NewRows([]string{"Master_Host"}))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeRelayLog(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without relay logs", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,31 @@
+// Scrape the server clock.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const serverTimeQuery = "SELECT UNIX_TIMESTAMP(NOW(6))"
+
+// Metric descriptors.
+var (
+	serverTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "server_time_seconds"),
+		"The current time of the server clock as a unix timestamp.",
+		nil, nil,
+	)
+)
+
+// ScrapeServerTime collects the current time of the server clock, so that
+// clock skew against the monitoring system can be alerted on.
+func ScrapeServerTime(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var now float64
+	if err := db.QueryRow(serverTimeQuery).Scan(&now); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(serverTimeDesc, prometheus.GaugeValue, now)
+	return nil
+}
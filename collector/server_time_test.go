@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeServerTime(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// A server clock an hour and a quarter second behind the exporter's.
+	serverTime := time.Now().Add(-time.Hour - 250*time.Millisecond)
+	rows := sqlmock.NewRows([]string{"UNIX_TIMESTAMP(NOW(6))"}).AddRow(float64(serverTime.UnixNano()) / 1e9)
+	mock.ExpectQuery(sanitizeQuery(serverTimeQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeServerTime(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("The server clock is reported, not the exporter's", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got.value, convey.ShouldAlmostEqual, float64(serverTime.UnixNano())/1e9, 1e-3)
+		skew := got.value - float64(time.Now().UnixNano())/1e9
+		convey.So(skew, convey.ShouldBeBetween, -3600.25-5, -3600.25)
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
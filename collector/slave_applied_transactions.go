@@ -0,0 +1,101 @@
+// Scrape the transactions applied by the replication applier workers from
+// `performance_schema.replication_applier_status_by_worker` and
+// `performance_schema.events_transactions_summary_by_thread_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Stopped workers have no THREAD_ID and drop out of the join.
+const slaveAppliedTransactionsQuery = `
+	SELECT w.CHANNEL_NAME, w.WORKER_ID, w.THREAD_ID, t.COUNT_STAR
+	  FROM performance_schema.replication_applier_status_by_worker w
+	  JOIN performance_schema.events_transactions_summary_by_thread_by_event_name t
+	    ON t.THREAD_ID = w.THREAD_ID
+	`
+
+// Metric descriptors.
+var (
+	slaveAppliedTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "applied_transactions_total"),
+		"The number of transactions applied by the applier workers of the channel.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// appliedTransactionsWorker identifies an applier worker thread.
+type appliedTransactionsWorker struct {
+	channelName, workerID, threadID string
+}
+
+// Applied transactions by worker thread seen by the previous scrape and the
+// totals by channel built from them, kept across scrapes.
+var (
+	appliedTransactionsMtx     sync.Mutex
+	appliedTransactionsWorkers = map[appliedTransactionsWorker]uint64{}
+	appliedTransactionsTotals  = map[string]uint64{}
+)
+
+// ScrapeAppliedTransactions collects the number of transactions applied by
+// each replication channel. The per thread counts restart from zero whenever
+// the workers are restarted or reconfigured, so the totals are accumulated
+// across scrapes.
+func ScrapeAppliedTransactions(db *sql.DB, ch chan<- prometheus.Metric) error {
+	appliedRows, err := db.Query(slaveAppliedTransactionsQuery)
+	if err != nil {
+		return err
+	}
+	defer appliedRows.Close()
+
+	var (
+		worker  appliedTransactionsWorker
+		count   uint64
+		workers = map[appliedTransactionsWorker]uint64{}
+	)
+	for appliedRows.Next() {
+		if err := appliedRows.Scan(&worker.channelName, &worker.workerID, &worker.threadID, &count); err != nil {
+			return err
+		}
+		workers[worker] = count
+	}
+	if err := appliedRows.Err(); err != nil {
+		return err
+	}
+
+	appliedTransactionsMtx.Lock()
+	channels := map[string]bool{}
+	for worker, count := range workers {
+		prev, ok := appliedTransactionsWorkers[worker]
+		if !ok || count < prev {
+			// A new worker thread, or its counters were truncated.
+			prev = 0
+		}
+		appliedTransactionsTotals[worker.channelName] += count - prev
+		channels[worker.channelName] = true
+	}
+	appliedTransactionsWorkers = workers
+	totals := make(map[string]uint64, len(channels))
+	for channelName := range channels {
+		totals[channelName] = appliedTransactionsTotals[channelName]
+	}
+	appliedTransactionsMtx.Unlock()
+
+	channelNames := make([]string, 0, len(totals))
+	for channelName := range totals {
+		channelNames = append(channelNames, channelName)
+	}
+	sort.Strings(channelNames)
+	for _, channelName := range channelNames {
+		ch <- prometheus.MustNewConstMetric(
+			slaveAppliedTransactionsDesc, prometheus.CounterValue, float64(totals[channelName]),
+			channelName,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeAppliedTransactions(t *testing.T) {
+	reset := func() {
+		appliedTransactionsWorkers = map[appliedTransactionsWorker]uint64{}
+		appliedTransactionsTotals = map[string]uint64{}
+	}
+	reset()
+	defer reset()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"CHANNEL_NAME", "WORKER_ID", "THREAD_ID", "COUNT_STAR"}
+	mock.ExpectQuery(sanitizeQuery(slaveAppliedTransactionsQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("", "1", "51", 100).
+		AddRow("", "2", "52", 50).
+		AddRow("backup", "1", "61", 7))
+	mock.ExpectQuery(sanitizeQuery(slaveAppliedTransactionsQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("", "1", "51", 130).
+		AddRow("", "2", "52", 60).
+		AddRow("backup", "1", "61", 7))
+	// The workers of the default channel were restarted with a third worker.
+	mock.ExpectQuery(sanitizeQuery(slaveAppliedTransactionsQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("", "1", "71", 4).
+		AddRow("", "2", "72", 3).
+		AddRow("", "3", "73", 1).
+		AddRow("backup", "1", "61", 9))
+
+	metricExpected := [][]MetricResult{
+		{
+			{labels: labelMap{"channel_name": ""}, value: 150, metricType: dto.MetricType_COUNTER},
+			{labels: labelMap{"channel_name": "backup"}, value: 7, metricType: dto.MetricType_COUNTER},
+		},
+		{
+			{labels: labelMap{"channel_name": ""}, value: 190, metricType: dto.MetricType_COUNTER},
+			{labels: labelMap{"channel_name": "backup"}, value: 7, metricType: dto.MetricType_COUNTER},
+		},
+		{
+			{labels: labelMap{"channel_name": ""}, value: 198, metricType: dto.MetricType_COUNTER},
+			{labels: labelMap{"channel_name": "backup"}, value: 9, metricType: dto.MetricType_COUNTER},
+		},
+	}
+	for _, expected := range metricExpected {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err := ScrapeAppliedTransactions(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		convey.Convey("Metrics comparison", t, func() {
+			for _, expect := range expected {
+				got := readMetric(<-ch)
+				convey.So(got, convey.ShouldResemble, expect)
+			}
+			_, ok := <-ch
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+	}
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Scrape how long the replication applier has been idle from
+// `performance_schema.replication_applier_status_by_worker`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+// A worker is applying a transaction while APPLYING_TRANSACTION is set, a
+// single threaded applier is reported as a single worker.
+const slaveApplierIdleQuery = `
+	SELECT
+	    CHANNEL_NAME,
+	    SUM(APPLYING_TRANSACTION != ''),
+	    ifnull(UNIX_TIMESTAMP(MAX(LAST_APPLIED_TRANSACTION_END_APPLY_TIMESTAMP)), 0),
+	    UNIX_TIMESTAMP(NOW(6))
+	  FROM performance_schema.replication_applier_status_by_worker
+	  GROUP BY CHANNEL_NAME
+	`
+
+// Metric descriptors.
+var (
+	slaveApplierIdleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "applier_idle_seconds"),
+		"Seconds since the applier of the channel last finished applying a transaction, 0 while it is applying one.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// ScrapeApplierIdle collects how long the applier of each channel has been
+// waiting for transactions to apply, available from MySQL 8.0. An idle applier
+// is healthy as long as mysql_slave_unapplied_transactions stays at zero, and
+// stuck when it does not.
+func ScrapeApplierIdle(db *sql.DB, ch chan<- prometheus.Metric) error {
+	exists, err := columnExists(db, "performance_schema", "replication_applier_status_by_worker", "LAST_APPLIED_TRANSACTION_END_APPLY_TIMESTAMP")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("performance_schema.replication_applier_status_by_worker has no applied transaction timestamps.")
+		return nil
+	}
+
+	idleRows, err := db.Query(slaveApplierIdleQuery)
+	if err != nil {
+		return err
+	}
+	defer idleRows.Close()
+
+	var (
+		channelName             string
+		applying                uint64
+		lastApplied, serverTime float64
+	)
+	for idleRows.Next() {
+		if err := idleRows.Scan(&channelName, &applying, &lastApplied, &serverTime); err != nil {
+			return err
+		}
+		// Without any applied transaction there is nothing to measure from.
+		if applying == 0 && lastApplied == 0 {
+			continue
+		}
+		idle := 0.0
+		if applying == 0 && serverTime > lastApplied {
+			idle = serverTime - lastApplied
+		}
+		ch <- prometheus.MustNewConstMetric(
+			slaveApplierIdleDesc, prometheus.GaugeValue, idle,
+			channelName,
+		)
+	}
+	return idleRows.Err()
+}
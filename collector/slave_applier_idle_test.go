@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeApplierIdle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(columnExistsQuery)).
+		WithArgs("performance_schema", "replication_applier_status_by_worker", "LAST_APPLIED_TRANSACTION_END_APPLY_TIMESTAMP").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+
+	columns := []string{"CHANNEL_NAME", "APPLYING", "LAST_APPLIED", "NOW"}
+	rows := sqlmock.NewRows(columns).
+		// Idle channel.
+		AddRow("", "0", "1500000000.25", "1500000010.5").
+		// Channel applying a transaction.
+		AddRow("busy", "2", "1500000010", "1500000010.5").
+		// Channel that never applied anything.
+		AddRow("new", "0", "0", "1500000010.5")
+	mock.ExpectQuery(sanitizeQuery(slaveApplierIdleQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeApplierIdle(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"channel_name": ""}, value: 10.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "busy"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
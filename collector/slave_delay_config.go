@@ -0,0 +1,60 @@
+// Scrape the configured replication delay from
+// `performance_schema.replication_applier_configuration`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const slaveDelayConfigQuery = `
+	SELECT CHANNEL_NAME, DESIRED_DELAY
+	  FROM performance_schema.replication_applier_configuration
+	`
+
+// Metric descriptors.
+var (
+	slaveConfiguredDelayDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "configured_delay_seconds"),
+		"The delay the channel is configured to apply transactions with, set by MASTER_DELAY.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// ScrapeReplicaDelayConfig collects the configured delay of each replication
+// channel, available from MySQL 5.7. Servers without any channel report
+// nothing.
+func ScrapeReplicaDelayConfig(db *sql.DB, ch chan<- prometheus.Metric) error {
+	available, err := perfSchemaTableAvailable(db, "replication_applier_configuration")
+	if err != nil {
+		return err
+	}
+	if !available {
+		log.Debugln("performance_schema.replication_applier_configuration is not available.")
+		return nil
+	}
+
+	delayRows, err := db.Query(slaveDelayConfigQuery)
+	if err != nil {
+		return err
+	}
+	defer delayRows.Close()
+
+	var (
+		channelName string
+		delay       float64
+	)
+	for delayRows.Next() {
+		if err := delayRows.Scan(&channelName, &delay); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			slaveConfiguredDelayDesc, prometheus.GaugeValue, delay,
+			channelName,
+		)
+	}
+	return delayRows.Err()
+}
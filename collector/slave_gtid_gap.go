@@ -0,0 +1,99 @@
+// Scrape the number of transactions a replica is behind its primary by
+// comparing their executed GTID sets.
+
+package collector
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const gtidExecutedQuery = `SELECT @@global.gtid_executed`
+
+var (
+	// gtidPrimaryDB is the connection to the primary, kept across scrapes.
+	gtidPrimaryDB    *sql.DB
+	gtidPrimaryDSN   string
+	gtidPrimaryDBMtx = &sync.Mutex{}
+)
+
+// Metric descriptors.
+var (
+	slaveGTIDGapDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "gtid_gap_transactions"),
+		"Number of transactions executed on the primary that the replica has not executed yet.",
+		[]string{"source_uuid"}, nil,
+	)
+)
+
+// ScrapeGTIDGap collects the number of transactions the replica is behind the
+// primary reachable at primaryDSN, by source UUID.
+func ScrapeGTIDGap(db *sql.DB, ch chan<- prometheus.Metric, primaryDSN string) error {
+	if primaryDSN == "" {
+		return errors.New("no primary DSN configured")
+	}
+	primary, err := openGTIDPrimary(primaryDSN)
+	if err != nil {
+		return err
+	}
+	return scrapeGTIDGap(db, primary, ch)
+}
+
+// openGTIDPrimary returns the connection to the primary, opening it on first
+// use.
+func openGTIDPrimary(dsn string) (*sql.DB, error) {
+	gtidPrimaryDBMtx.Lock()
+	defer gtidPrimaryDBMtx.Unlock()
+	if gtidPrimaryDB != nil && gtidPrimaryDSN == dsn {
+		return gtidPrimaryDB, nil
+	}
+	primary, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	primary.SetMaxOpenConns(1)
+	if gtidPrimaryDB != nil {
+		gtidPrimaryDB.Close()
+	}
+	gtidPrimaryDB, gtidPrimaryDSN = primary, dsn
+	return primary, nil
+}
+
+func scrapeGTIDGap(replica, primary *sql.DB, ch chan<- prometheus.Metric) error {
+	// Read the replica first, so that transactions committed in between
+	// do not make it look ahead of the primary.
+	replicaSet, err := queryGTIDExecuted(replica)
+	if err != nil {
+		return err
+	}
+	primarySet, err := queryGTIDExecuted(primary)
+	if err != nil {
+		return err
+	}
+
+	missing := primarySet.missing(replicaSet)
+	sources := make([]string, 0, len(missing))
+	for source := range missing {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		ch <- prometheus.MustNewConstMetric(
+			slaveGTIDGapDesc, prometheus.GaugeValue, float64(missing[source]),
+			source,
+		)
+	}
+	return nil
+}
+
+func queryGTIDExecuted(db *sql.DB) (gtidSet, error) {
+	var executed string
+	if err := db.QueryRow(gtidExecutedQuery).Scan(&executed); err != nil {
+		return nil, err
+	}
+	return parseGTIDSet(executed)
+}
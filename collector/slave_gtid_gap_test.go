@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeGTIDGap(t *testing.T) {
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer replica.Close()
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer primary.Close()
+
+	replicaMock.ExpectQuery(sanitizeQuery(gtidExecutedQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(gtidUUID1 + ":1-95," + gtidUUID2 + ":1-10"))
+	primaryMock.ExpectQuery(sanitizeQuery(gtidExecutedQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(gtidUUID1 + ":1-100," + gtidUUID2 + ":1-10"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = scrapeGTIDGap(replica, primary, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"source_uuid": gtidUUID2}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"source_uuid": gtidUUID1}, value: 5, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Scrape a replica health score for load balancers from `SHOW SLAVE STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const replicaReadOnlyQuery = `SELECT @@global.read_only`
+
+// Tuning flags.
+var (
+	replicaHealthLagHealthy = kingpin.Flag(
+		"collect.slave_health.lag_healthy_seconds",
+		"Replication lag in seconds up to which a replica scores 1",
+	).Default("10").Int()
+	replicaHealthLagUnhealthy = kingpin.Flag(
+		"collect.slave_health.lag_unhealthy_seconds",
+		"Replication lag in seconds from which a replica scores 0",
+	).Default("300").Int()
+)
+
+// Metric descriptors.
+var (
+	replicaHealthScoreDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "replica_health_score"),
+		"Health of the replica for serving reads from 0 to 1, see the README for the formula.",
+		nil, nil,
+	)
+)
+
+// ScrapeReplicaHealth collects a score of how suitable the replica is for
+// serving reads, for proxies to weight replicas by. Servers that are not
+// replicas are not scored.
+func ScrapeReplicaHealth(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var readOnly bool
+	if err := db.QueryRow(replicaReadOnlyQuery).Scan(&readOnly); err != nil {
+		return err
+	}
+
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	defer slaveStatusRows.Close()
+
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	score, replica := 1.0, false
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+		replica = true
+
+		// The least healthy channel decides.
+		channelScore := replicaChannelHealth(
+			columnValue(scanArgs, slaveCols, "Slave_IO_Running"),
+			columnValue(scanArgs, slaveCols, "Slave_SQL_Running"),
+			columnValue(scanArgs, slaveCols, "Seconds_Behind_Master"),
+		)
+		if channelScore < score {
+			score = channelScore
+		}
+	}
+	if err := slaveStatusRows.Err(); err != nil {
+		return err
+	}
+	if !replica {
+		return nil
+	}
+
+	if !readOnly {
+		score /= 2
+	}
+	ch <- prometheus.MustNewConstMetric(replicaHealthScoreDesc, prometheus.GaugeValue, score)
+	return nil
+}
+
+// replicaChannelHealth scores a replication channel from its thread states and
+// lag: 0 unless both threads run and the lag is known, otherwise 1 up to
+// the healthy lag, 0 from the unhealthy lag and linear in between.
+func replicaChannelHealth(ioRunning, sqlRunning, secondsBehind string) float64 {
+	if ioRunning != "Yes" || sqlRunning != "Yes" {
+		return 0
+	}
+	lag, err := strconv.ParseFloat(secondsBehind, 64)
+	if err != nil {
+		return 0
+	}
+	healthy, unhealthy := float64(*replicaHealthLagHealthy), float64(*replicaHealthLagUnhealthy)
+	switch {
+	case lag <= healthy:
+		return 1
+	case lag >= unhealthy:
+		return 0
+	}
+	return (unhealthy - lag) / (unhealthy - healthy)
+}
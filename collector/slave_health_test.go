@@ -0,0 +1,71 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicaHealth(t *testing.T) {
+	healthy, unhealthy := *replicaHealthLagHealthy, *replicaHealthLagUnhealthy
+	*replicaHealthLagHealthy, *replicaHealthLagUnhealthy = 10, 110
+	defer func() { *replicaHealthLagHealthy, *replicaHealthLagUnhealthy = healthy, unhealthy }()
+
+	columns := []string{"Channel_Name", "Slave_IO_Running", "Slave_SQL_Running", "Seconds_Behind_Master"}
+	tests := []struct {
+		name     string
+		readOnly int
+		rows     [][]string
+		score    float64
+		scored   bool
+	}{
+		{"not a replica", 0, nil, 0, false},
+		{"no lag", 1, [][]string{{"", "Yes", "Yes", "0"}}, 1, true},
+		{"some lag", 1, [][]string{{"", "Yes", "Yes", "35"}}, 0.75, true},
+		{"too much lag", 1, [][]string{{"", "Yes", "Yes", "600"}}, 0, true},
+		{"connecting", 1, [][]string{{"", "Connecting", "Yes", ""}}, 0, true},
+		{"SQL thread stopped", 1, [][]string{{"", "Yes", "No", ""}}, 0, true},
+		{"writable", 0, [][]string{{"", "Yes", "Yes", "0"}}, 0.5, true},
+		{"least healthy channel", 1, [][]string{{"a", "Yes", "Yes", "0"}, {"b", "Yes", "Yes", "60"}}, 0.5, true},
+	}
+	for _, test := range tests {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+
+		mock.ExpectQuery(sanitizeQuery(replicaReadOnlyQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"@@global.read_only"}).AddRow(test.readOnly))
+		rows := sqlmock.NewRows(columns)
+		for _, row := range test.rows {
+			rows.AddRow(row[0], row[1], row[2], row[3])
+		}
+		mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err := ScrapeReplicaHealth(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		convey.Convey("Metrics comparison: "+test.name, t, func() {
+			if test.scored {
+				got := readMetric(<-ch)
+				convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: test.score, metricType: dto.MetricType_GAUGE})
+			}
+			_, ok := <-ch
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+
+		// Ensure all SQL queries were executed
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%s: there were unfulfilled expections: %s", test.name, err)
+		}
+		db.Close()
+	}
+}
@@ -0,0 +1,92 @@
+// Scrape the server ids of a replica and its sources to spot replication
+// loops.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const serverIdentityQuery = `SELECT @@server_id, @@server_uuid`
+
+// Metric descriptors.
+var (
+	serverIDDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "server_id"),
+		"The server_id of the server.",
+		nil, nil,
+	)
+	slaveMasterServerIDDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "master_server_id"),
+		"The server_id of the source the replica replicates from.",
+		[]string{"channel_name", "connection_name"}, nil,
+	)
+	replicationLoopDetectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "replication", "loop_detected"),
+		"Whether the server replicates from itself, 1 if so.",
+		nil, nil,
+	)
+)
+
+// ScrapeReplicationLoop collects the server_id of the server and of each of its
+// sources, and whether any source is the server itself. The exporter only
+// knows about its own server, so loops spanning several servers have to be
+// found by comparing these ids across targets.
+func ScrapeReplicationLoop(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var (
+		serverID   uint64
+		serverUUID string
+	)
+	if err := db.QueryRow(serverIdentityQuery).Scan(&serverID, &serverUUID); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(serverIDDesc, prometheus.GaugeValue, float64(serverID))
+
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	defer slaveStatusRows.Close()
+
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	var loop float64
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+
+		channelName := columnValue(scanArgs, slaveCols, "Channel_Name")       // MySQL & Percona
+		connectionName := columnValue(scanArgs, slaveCols, "Connection_name") // MariaDB
+
+		masterServerID, err := strconv.ParseUint(columnValue(scanArgs, slaveCols, "Master_Server_Id"), 10, 64)
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			slaveMasterServerIDDesc, prometheus.GaugeValue, float64(masterServerID),
+			channelName, connectionName,
+		)
+
+		masterUUID := columnValue(scanArgs, slaveCols, "Master_UUID")
+		if masterServerID == serverID || (masterUUID != "" && masterUUID == serverUUID) {
+			loop = 1
+		}
+	}
+	if err := slaveStatusRows.Err(); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(replicationLoopDetectedDesc, prometheus.GaugeValue, loop)
+	return nil
+}
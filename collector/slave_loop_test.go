@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicationLoop(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(serverIdentityQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@server_id", "@@server_uuid"}).
+			AddRow("1", "3e11fa47-71ca-11e1-9e33-c80aa9429562"))
+	// Server 1 replicates from server 2, which replicates from server 1: on
+	// server 1 only the first hop is visible, the loop is not.
+	columns := []string{"Master_Server_Id", "Master_UUID", "Channel_Name"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("2", "4f22fb58-71ca-11e1-9e33-c80aa9429562", "from_2")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeReplicationLoop(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "from_2", "connection_name": ""}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeReplicationLoopDetected(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(serverIdentityQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@server_id", "@@server_uuid"}).
+			AddRow("1", "3e11fa47-71ca-11e1-9e33-c80aa9429562"))
+	// The second channel points back at the server itself, under another
+	// server_id the UUID still gives it away.
+	columns := []string{"Master_Server_Id", "Master_UUID", "Channel_Name"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("2", "4f22fb58-71ca-11e1-9e33-c80aa9429562", "from_2").
+		AddRow("3", "3e11fa47-71ca-11e1-9e33-c80aa9429562", "from_self")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeReplicationLoop(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "from_2", "connection_name": ""}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "from_self", "connection_name": ""}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Scrape the multi-threaded replication settings from `SHOW GLOBAL VARIABLES`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const slaveParallelConfigQuery = `
+	SHOW GLOBAL VARIABLES
+	  WHERE Variable_name IN (
+	    'slave_parallel_type', 'replica_parallel_type',
+	    'slave_parallel_workers', 'replica_parallel_workers',
+	    'binlog_transaction_dependency_tracking'
+	  )
+	`
+
+// slaveParallelConfigAliases maps the variable names introduced by MySQL
+// 8.0.26 to their older names. Servers having both report the same value
+// under either name.
+var slaveParallelConfigAliases = map[string]string{
+	"replica_parallel_type":    "slave_parallel_type",
+	"replica_parallel_workers": "slave_parallel_workers",
+}
+
+// Metric descriptors.
+var (
+	slaveParallelWorkersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "parallel_workers"),
+		"The number of applier workers configured by slave_parallel_workers, 0 for a single threaded applier.",
+		nil, nil,
+	)
+	slaveParallelTypeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "parallel_type_info"),
+		"The policy deciding which transactions apply in parallel from slave_parallel_type, with a constant value of 1.",
+		[]string{"type"}, nil,
+	)
+	binlogDependencyTrackingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "transaction_dependency_tracking_info"),
+		"The source of the dependency information written to the binary log from binlog_transaction_dependency_tracking, with a constant value of 1.",
+		[]string{"mode"}, nil,
+	)
+)
+
+// ScrapeParallelReplicationConfig collects the multi-threaded replication
+// settings, for auditing them across replicas. Settings the server does not
+// have are skipped.
+func ScrapeParallelReplicationConfig(db *sql.DB, ch chan<- prometheus.Metric) error {
+	configRows, err := db.Query(slaveParallelConfigQuery)
+	if err != nil {
+		return err
+	}
+	defer configRows.Close()
+
+	var (
+		key, val string
+		settings = map[string]string{}
+	)
+	for configRows.Next() {
+		if err := configRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		key = strings.ToLower(key)
+		if alias, ok := slaveParallelConfigAliases[key]; ok {
+			key = alias
+		}
+		settings[key] = val
+	}
+	if err := configRows.Err(); err != nil {
+		return err
+	}
+
+	if workers, ok := settings["slave_parallel_workers"]; ok {
+		if floatVal, err := strconv.ParseFloat(workers, 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(slaveParallelWorkersDesc, prometheus.GaugeValue, floatVal)
+		}
+	}
+	if parallelType, ok := settings["slave_parallel_type"]; ok {
+		ch <- prometheus.MustNewConstMetric(slaveParallelTypeDesc, prometheus.GaugeValue, 1, parallelType)
+	}
+	if tracking, ok := settings["binlog_transaction_dependency_tracking"]; ok {
+		ch <- prometheus.MustNewConstMetric(binlogDependencyTrackingDesc, prometheus.GaugeValue, 1, tracking)
+	}
+	return nil
+}
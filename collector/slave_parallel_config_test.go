@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeParallelReplicationConfig(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("binlog_transaction_dependency_tracking", "WRITESET").
+		AddRow("replica_parallel_type", "LOGICAL_CLOCK").
+		AddRow("replica_parallel_workers", "4").
+		AddRow("slave_parallel_type", "LOGICAL_CLOCK").
+		AddRow("slave_parallel_workers", "4")
+	mock.ExpectQuery(sanitizeQuery(slaveParallelConfigQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeParallelReplicationConfig(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"type": "LOGICAL_CLOCK"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"mode": "WRITESET"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
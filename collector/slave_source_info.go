@@ -0,0 +1,61 @@
+// Scrape where each replication channel replicates from with
+// `SHOW SLAVE STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric descriptors.
+var (
+	slaveSourceInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "source_info"),
+		"The source host and port of the replication channel and whether it uses GTID auto-positioning, with a constant value of 1.",
+		[]string{"channel_name", "connection_name", "host", "port", "auto_position"}, nil,
+	)
+)
+
+// ScrapeReplicationConfig collects the source each replication channel is
+// configured to replicate from. Servers without channels report nothing.
+func ScrapeReplicationConfig(db *sql.DB, ch chan<- prometheus.Metric) error {
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	defer slaveStatusRows.Close()
+
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+
+		autoPosition := columnValue(scanArgs, slaveCols, "Auto_Position") // MySQL & Percona
+		if columnIndex(slaveCols, "Using_Gtid") != -1 {                   // MariaDB
+			autoPosition = "1"
+			if columnValue(scanArgs, slaveCols, "Using_Gtid") == "No" {
+				autoPosition = "0"
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			slaveSourceInfoDesc, prometheus.GaugeValue, 1,
+			columnValue(scanArgs, slaveCols, "Channel_Name"),
+			columnValue(scanArgs, slaveCols, "Connection_name"),
+			columnValue(scanArgs, slaveCols, "Master_Host"),
+			columnValue(scanArgs, slaveCols, "Master_Port"),
+			autoPosition,
+		)
+	}
+	return slaveStatusRows.Err()
+}
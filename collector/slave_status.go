@@ -5,6 +5,7 @@ package collector
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,8 +14,56 @@ import (
 const (
 	// Subsystem.
 	slaveStatus = "slave_status"
+	// Subsystem for replication metrics derived from other sources.
+	slave = "slave"
 )
 
+// slaveErrorMaxLength bounds the length of the replication thread errors
+// reported as labels.
+const slaveErrorMaxLength = 200
+
+// Metric descriptors.
+var (
+	slaveSQLLastErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "sql_last_error"),
+		"The last error, truncated, of the SQL thread while it is stopped, with a constant value of 1.",
+		[]string{"channel_name", "connection_name", "error"}, nil,
+	)
+	slaveIOLastErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "io_last_error"),
+		"The last error, truncated, of the IO thread while it is not running, with a constant value of 1.",
+		[]string{"channel_name", "connection_name", "error"}, nil,
+	)
+	slaveIOThreadStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "io_thread_state"),
+		"State of the IO thread from Slave_IO_Running: 0 for No, 1 for Yes, 2 for Connecting.",
+		[]string{"channel_name", "connection_name"}, nil,
+	)
+	slaveSQLThreadStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "sql_thread_state"),
+		"State of the SQL thread from Slave_SQL_Running: 0 for No, 1 for Yes.",
+		[]string{"channel_name", "connection_name"}, nil,
+	)
+	slaveIOStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "io_state"),
+		"What the IO thread is doing from Slave_IO_State, with a constant value of 1.",
+		[]string{"channel_name", "connection_name", "state"}, nil,
+	)
+	slaveRelayLogBacklogDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "relay_log_backlog_bytes"),
+		"Bytes of the source binary log fetched by the IO thread but not yet executed by the SQL thread.",
+		[]string{"channel_name", "connection_name"}, nil,
+	)
+)
+
+// slaveThreadStates maps the values of Slave_IO_Running and
+// Slave_SQL_Running to thread states.
+var slaveThreadStates = map[string]float64{
+	"No":         0,
+	"Yes":        1,
+	"Connecting": 2,
+}
+
 var slaveStatusQueries = [2]string{"SHOW ALL SLAVES STATUS", "SHOW SLAVE STATUS"}
 var slaveStatusQuerySuffixes = [3]string{" NONBLOCKING", " NOLOCK", ""}
 
@@ -35,8 +84,8 @@ func columnValue(scanArgs []interface{}, slaveCols []string, colName string) str
 	return string(*scanArgs[columnIndex].(*sql.RawBytes))
 }
 
-// ScrapeSlaveStatus collects from `SHOW SLAVE STATUS`.
-func ScrapeSlaveStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
+// querySlaveStatus runs `SHOW SLAVE STATUS` with the syntax supported by the server.
+func querySlaveStatus(db *sql.DB) (*sql.Rows, error) {
 	var (
 		slaveStatusRows *sql.Rows
 		err             error
@@ -56,6 +105,12 @@ func ScrapeSlaveStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
 			break
 		}
 	}
+	return slaveStatusRows, err
+}
+
+// ScrapeSlaveStatus collects from `SHOW SLAVE STATUS`.
+func ScrapeSlaveStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
+	slaveStatusRows, err := querySlaveStatus(db)
 	if err != nil {
 		return err
 	}
@@ -99,6 +154,73 @@ func ScrapeSlaveStatus(db *sql.DB, ch chan<- prometheus.Metric) error {
 				)
 			}
 		}
+
+		scrapeSlaveThreadError(ch, scanArgs, slaveCols, "Slave_SQL_Running", "Last_SQL_Error",
+			slaveSQLLastErrorDesc, channelName, connectionName)
+		scrapeSlaveThreadError(ch, scanArgs, slaveCols, "Slave_IO_Running", "Last_IO_Error",
+			slaveIOLastErrorDesc, channelName, connectionName)
+		scrapeSlaveRelayLogBacklog(ch, scanArgs, slaveCols, channelName, connectionName)
+		scrapeSlaveThreadStates(ch, scanArgs, slaveCols, channelName, connectionName)
 	}
 	return nil
 }
+
+// scrapeSlaveThreadStates reports the states of the IO and SQL threads
+// separately, along with what the IO thread is doing.
+func scrapeSlaveThreadStates(
+	ch chan<- prometheus.Metric, scanArgs []interface{}, slaveCols []string,
+	channelName, connectionName string,
+) {
+	if state, ok := slaveThreadStates[columnValue(scanArgs, slaveCols, "Slave_IO_Running")]; ok {
+		ch <- prometheus.MustNewConstMetric(slaveIOThreadStateDesc, prometheus.GaugeValue, state, channelName, connectionName)
+	}
+	if state, ok := slaveThreadStates[columnValue(scanArgs, slaveCols, "Slave_SQL_Running")]; ok {
+		ch <- prometheus.MustNewConstMetric(slaveSQLThreadStateDesc, prometheus.GaugeValue, state, channelName, connectionName)
+	}
+	if columnIndex(slaveCols, "Slave_IO_State") != -1 {
+		ch <- prometheus.MustNewConstMetric(
+			slaveIOStateDesc, prometheus.GaugeValue, 1,
+			channelName, connectionName, columnValue(scanArgs, slaveCols, "Slave_IO_State"),
+		)
+	}
+}
+
+// scrapeSlaveRelayLogBacklog reports how far the SQL thread is behind the IO
+// thread in the source binary log. Positions in different files can't be
+// compared, so nothing is reported until both threads are in the same file.
+func scrapeSlaveRelayLogBacklog(
+	ch chan<- prometheus.Metric, scanArgs []interface{}, slaveCols []string,
+	channelName, connectionName string,
+) {
+	readFile := columnValue(scanArgs, slaveCols, "Master_Log_File")
+	if readFile == "" || readFile != columnValue(scanArgs, slaveCols, "Relay_Master_Log_File") {
+		return
+	}
+	readPos, err := strconv.ParseFloat(columnValue(scanArgs, slaveCols, "Read_Master_Log_Pos"), 64)
+	if err != nil {
+		return
+	}
+	execPos, err := strconv.ParseFloat(columnValue(scanArgs, slaveCols, "Exec_Master_Log_Pos"), 64)
+	if err != nil || execPos > readPos {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(slaveRelayLogBacklogDesc, prometheus.GaugeValue, readPos-execPos, channelName, connectionName)
+}
+
+// scrapeSlaveThreadError reports the last error message of a replication
+// thread while it is not running. The error number is already reported as
+// mysql_slave_status_last_sql_errno and mysql_slave_status_last_io_errno.
+func scrapeSlaveThreadError(
+	ch chan<- prometheus.Metric, scanArgs []interface{}, slaveCols []string,
+	runningCol, errorCol string, errorDesc *prometheus.Desc,
+	channelName, connectionName string,
+) {
+	lastError := columnValue(scanArgs, slaveCols, errorCol)
+	if lastError == "" || columnValue(scanArgs, slaveCols, runningCol) == "Yes" {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		errorDesc, prometheus.GaugeValue, 1,
+		channelName, connectionName, truncateQuery(lastError, slaveErrorMaxLength),
+	)
+}
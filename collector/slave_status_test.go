@@ -47,3 +47,142 @@ func TestScrapeSlaveStatus(t *testing.T) {
 		t.Errorf("there were unfulfilled expections: %s", err)
 	}
 }
+
+func TestScrapeSlaveStatusThreadErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Channel_Name", "Slave_IO_Running", "Slave_SQL_Running", "Last_IO_Errno", "Last_IO_Error", "Last_SQL_Errno", "Last_SQL_Error"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", "Yes", "No", "0", "", "1062",
+			"Could not execute Write_rows event on table app.users; Duplicate entry '42' for key 'PRIMARY'")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSlaveStatus(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	var metrics []prometheus.Metric
+	for m := range ch {
+		switch m.Desc() {
+		case slaveSQLLastErrorDesc, slaveIOLastErrorDesc:
+			metrics = append(metrics, m)
+		}
+	}
+
+	convey.Convey("Replication thread errors", t, func() {
+		// The running IO thread does not report its error.
+		convey.So(metrics, convey.ShouldHaveLength, 1)
+		convey.So(metrics[0].Desc(), convey.ShouldEqual, slaveSQLLastErrorDesc)
+		convey.So(readMetric(metrics[0]), convey.ShouldResemble, MetricResult{labels: labelMap{
+			"channel_name": "", "connection_name": "",
+			"error": "Could not execute Write_rows event on table app.users; Duplicate entry '42' for key 'PRIMARY'",
+		}, value: 1, metricType: dto.MetricType_GAUGE})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeSlaveStatusRelayLogBacklog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Channel_Name", "Master_Log_File", "Read_Master_Log_Pos", "Relay_Master_Log_File", "Exec_Master_Log_Pos"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("same_file", "mysql-bin.000042", "123456", "mysql-bin.000042", "100000").
+		// The SQL thread is still in an older file, no backlog is reported.
+		AddRow("other_file", "mysql-bin.000043", "500", "mysql-bin.000042", "100000")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeSlaveStatus(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	var metrics []prometheus.Metric
+	for m := range ch {
+		if m.Desc() == slaveRelayLogBacklogDesc {
+			metrics = append(metrics, m)
+		}
+	}
+
+	convey.Convey("Relay log backlog", t, func() {
+		convey.So(metrics, convey.ShouldHaveLength, 1)
+		convey.So(readMetric(metrics[0]), convey.ShouldResemble, MetricResult{labels: labelMap{"channel_name": "same_file", "connection_name": ""}, value: 23456, metricType: dto.MetricType_GAUGE})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeSlaveStatusThreadStates(t *testing.T) {
+	for _, tc := range []struct {
+		ioRunning, sqlRunning, ioState string
+		ioThread, sqlThread            float64
+	}{
+		{"Yes", "Yes", "Waiting for master to send event", 1, 1},
+		{"Yes", "No", "Waiting for master to send event", 1, 0},
+		{"No", "Yes", "", 0, 1},
+		{"No", "No", "", 0, 0},
+		{"Connecting", "Yes", "Connecting to master", 2, 1},
+		{"Connecting", "No", "Connecting to master", 2, 0},
+	} {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("error opening a stub database connection: %s", err)
+		}
+
+		columns := []string{"Slave_IO_State", "Slave_IO_Running", "Slave_SQL_Running"}
+		rows := sqlmock.NewRows(columns).AddRow(tc.ioState, tc.ioRunning, tc.sqlRunning)
+		mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			if err := ScrapeSlaveStatus(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+			close(ch)
+		}()
+
+		got := map[*prometheus.Desc]MetricResult{}
+		for m := range ch {
+			switch m.Desc() {
+			case slaveIOThreadStateDesc, slaveSQLThreadStateDesc, slaveIOStateDesc:
+				got[m.Desc()] = readMetric(m)
+			}
+		}
+
+		convey.Convey("Thread states with IO "+tc.ioRunning+" and SQL "+tc.sqlRunning, t, func() {
+			labels := labelMap{"channel_name": "", "connection_name": ""}
+			convey.So(got[slaveIOThreadStateDesc], convey.ShouldResemble, MetricResult{labels: labels, value: tc.ioThread, metricType: dto.MetricType_GAUGE})
+			convey.So(got[slaveSQLThreadStateDesc], convey.ShouldResemble, MetricResult{labels: labels, value: tc.sqlThread, metricType: dto.MetricType_GAUGE})
+			convey.So(got[slaveIOStateDesc], convey.ShouldResemble, MetricResult{
+				labels: labelMap{"channel_name": "", "connection_name": "", "state": tc.ioState}, value: 1, metricType: dto.MetricType_GAUGE,
+			})
+		})
+
+		// Ensure all SQL queries were executed
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expections: %s", err)
+		}
+		db.Close()
+	}
+}
@@ -0,0 +1,75 @@
+// Scrape the number of transactions a replica received but has not applied
+// yet from `performance_schema.replication_connection_status`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const receivedTransactionSetQuery = `
+	SELECT CHANNEL_NAME, RECEIVED_TRANSACTION_SET
+	  FROM performance_schema.replication_connection_status
+	`
+
+// Metric descriptors.
+var (
+	slaveUnappliedTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slave, "unapplied_transactions"),
+		"Number of transactions received by the channel that have not been applied yet.",
+		[]string{"channel_name"}, nil,
+	)
+)
+
+// ScrapeUnappliedTransactions collects, by channel, the number of GTIDs in the
+// received transaction set that are not in gtid_executed.
+func ScrapeUnappliedTransactions(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Read the received sets first, so that transactions received in
+	// between do not count as unapplied.
+	receivedRows, err := db.Query(receivedTransactionSetQuery)
+	if err != nil {
+		return err
+	}
+	defer receivedRows.Close()
+
+	var (
+		channelName, received string
+		channels              []string
+		receivedSets          = map[string]gtidSet{}
+	)
+	for receivedRows.Next() {
+		if err := receivedRows.Scan(&channelName, &received); err != nil {
+			return err
+		}
+		set, err := parseGTIDSet(received)
+		if err != nil {
+			return err
+		}
+		channels = append(channels, channelName)
+		receivedSets[channelName] = set
+	}
+	if err := receivedRows.Err(); err != nil {
+		return err
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	executed, err := queryGTIDExecuted(db)
+	if err != nil {
+		return err
+	}
+	for _, channelName := range channels {
+		var unapplied uint64
+		for _, count := range receivedSets[channelName].missing(executed) {
+			unapplied += count
+		}
+		ch <- prometheus.MustNewConstMetric(
+			slaveUnappliedTransactionsDesc, prometheus.GaugeValue, float64(unapplied),
+			channelName,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeUnappliedTransactions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"CHANNEL_NAME", "RECEIVED_TRANSACTION_SET"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-100").
+		AddRow("analytics", "4f22fb58-71ca-11e1-9e33-c80aa9429562:1-20:31-40").
+		AddRow("idle", "")
+	mock.ExpectQuery(sanitizeQuery(receivedTransactionSetQuery)).WillReturnRows(rows)
+	// The default channel applied up to 90, the analytics one all but 36-40.
+	mock.ExpectQuery(sanitizeQuery(gtidExecutedQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"@@global.gtid_executed"}).AddRow(
+			"3E11FA47-71CA-11E1-9E33-C80AA9429562:1-90,\n4f22fb58-71ca-11e1-9e33-c80aa9429562:1-35"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeUnappliedTransactions(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"channel_name": ""}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "analytics"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "idle"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Scrape the statements by statement type from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statementMixQuery = `
+	SHOW GLOBAL STATUS
+	  WHERE Variable_name IN (
+	    'Questions', 'Com_select',
+	    'Com_insert', 'Com_insert_select', 'Com_replace', 'Com_replace_select',
+	    'Com_update', 'Com_update_multi', 'Com_delete', 'Com_delete_multi'
+	  )
+	`
+
+// statementMixTypes maps the status variables to the statement type they
+// count towards, everything else in Questions is "other".
+var statementMixTypes = map[string]string{
+	"Com_select":         "select",
+	"Com_insert":         "insert",
+	"Com_insert_select":  "insert",
+	"Com_replace":        "insert",
+	"Com_replace_select": "insert",
+	"Com_update":         "update",
+	"Com_update_multi":   "update",
+	"Com_delete":         "delete",
+	"Com_delete_multi":   "delete",
+}
+
+// statementMixOrder is the order the statement types are reported in.
+var statementMixOrder = []string{"select", "insert", "update", "delete", "other"}
+
+// Metric descriptors.
+var (
+	statementsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "statements_total"),
+		"Total number of statements by statement type, the mix is the rate of each type over the rate of all of them.",
+		[]string{"type"}, nil,
+	)
+)
+
+// ScrapeStatementMix collects the number of select, insert, update, delete
+// and other statements. Questions not counted by any of the types are
+// reported as "other".
+func ScrapeStatementMix(db *sql.DB, ch chan<- prometheus.Metric) error {
+	statusRows, err := db.Query(statementMixQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key       string
+		val       sql.RawBytes
+		questions float64
+		counters  = map[string]float64{}
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		if key == "Questions" {
+			questions = floatVal
+		} else if statementType, ok := statementMixTypes[key]; ok {
+			counters[statementType] += floatVal
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	counters["other"] = questions - counters["select"] - counters["insert"] - counters["update"] - counters["delete"]
+	if counters["other"] < 0 {
+		counters["other"] = 0
+	}
+	for _, statementType := range statementMixOrder {
+		ch <- prometheus.MustNewConstMetric(
+			statementsDesc, prometheus.CounterValue, counters[statementType],
+			statementType,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeStatementMix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(statementMixQuery)).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("Com_delete", "110").
+		AddRow("Com_delete_multi", "0").
+		AddRow("Com_insert", "220").
+		AddRow("Com_insert_select", "0").
+		AddRow("Com_select", "1060").
+		AddRow("Com_update", "305").
+		AddRow("Com_update_multi", "5").
+		AddRow("Questions", "2100"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeStatementMix(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"type": "select"}, value: 1060, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"type": "insert"}, value: 220, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"type": "update"}, value: 310, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"type": "delete"}, value: 110, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"type": "other"}, value: 400, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
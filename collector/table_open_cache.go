@@ -0,0 +1,100 @@
+// Scrape table open cache efficiency from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	tableOpenCache = "table_open_cache"
+	// Queries.
+	tableOpenCacheSettingsQuery = `SELECT @@table_open_cache, @@table_open_cache_instances`
+	tableOpenCacheStatusQuery   = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN (
+		    'Table_open_cache_hits', 'Table_open_cache_misses', 'Table_open_cache_overflows'
+		  )
+		`
+)
+
+// Metric descriptors.
+var (
+	tableOpenCacheSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableOpenCache, "size"),
+		"The number of open tables the cache holds across its instances.",
+		nil, nil,
+	)
+	tableOpenCacheInstancesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableOpenCache, "instances"),
+		"The number of table open cache instances.",
+		nil, nil,
+	)
+	tableOpenCacheOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableOpenCache, "lookups_total"),
+		"Total number of table open cache lookups by result.",
+		[]string{"result"}, nil,
+	)
+	tableOpenCacheOverflowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableOpenCache, "overflows_total"),
+		"Total number of times an unused table was removed after opening a table, summed over the instances.",
+		nil, nil,
+	)
+	tableOpenCacheMissRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableOpenCache, "miss_ratio"),
+		"Ratio of table open cache misses to lookups since the server started.",
+		nil, nil,
+	)
+)
+
+// ScrapeTableOpenCache collects table open cache efficiency counters.
+func ScrapeTableOpenCache(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var size, instances float64
+	if err := db.QueryRow(tableOpenCacheSettingsQuery).Scan(&size, &instances); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(tableOpenCacheSizeDesc, prometheus.GaugeValue, size)
+	ch <- prometheus.MustNewConstMetric(tableOpenCacheInstancesDesc, prometheus.GaugeValue, instances)
+
+	statusRows, err := db.Query(tableOpenCacheStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key          string
+		val          sql.RawBytes
+		hits, misses float64
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Table_open_cache_hits":
+			hits = floatVal
+			ch <- prometheus.MustNewConstMetric(tableOpenCacheOpsDesc, prometheus.CounterValue, floatVal, "hit")
+		case "Table_open_cache_misses":
+			misses = floatVal
+			ch <- prometheus.MustNewConstMetric(tableOpenCacheOpsDesc, prometheus.CounterValue, floatVal, "miss")
+		case "Table_open_cache_overflows":
+			ch <- prometheus.MustNewConstMetric(tableOpenCacheOverflowsDesc, prometheus.CounterValue, floatVal)
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	if hits+misses > 0 {
+		ch <- prometheus.MustNewConstMetric(tableOpenCacheMissRatioDesc, prometheus.GaugeValue, misses/(hits+misses))
+	}
+	return nil
+}
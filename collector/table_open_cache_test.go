@@ -0,0 +1,96 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeTableOpenCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableOpenCacheSettingsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@table_open_cache", "@@table_open_cache_instances"}).AddRow(4000, 16))
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Table_open_cache_hits", "9000").
+		AddRow("Table_open_cache_misses", "1000").
+		AddRow("Table_open_cache_overflows", "250")
+	mock.ExpectQuery(sanitizeQuery(tableOpenCacheStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTableOpenCache(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 4000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 16, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"result": "hit"}, value: 9000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"result": "miss"}, value: 1000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 250, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 0.1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeTableOpenCacheNoLookups(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tableOpenCacheSettingsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@table_open_cache", "@@table_open_cache_instances"}).AddRow(4000, 16))
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Table_open_cache_hits", "0").
+		AddRow("Table_open_cache_misses", "0").
+		AddRow("Table_open_cache_overflows", "0")
+	mock.ExpectQuery(sanitizeQuery(tableOpenCacheStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTableOpenCache(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No miss ratio without lookups", t, func() {
+		count := 0
+		for m := range ch {
+			convey.So(m.Desc(), convey.ShouldNotEqual, tableOpenCacheMissRatioDesc)
+			count++
+		}
+		convey.So(count, convey.ShouldEqual, 5)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
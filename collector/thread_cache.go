@@ -0,0 +1,100 @@
+// Scrape connection thread cache efficiency from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	threadCache = "thread_cache"
+	// Queries.
+	threadCacheSizeQuery   = `SELECT @@thread_cache_size`
+	threadCacheStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Threads_created', 'Threads_cached', 'Connections')
+		`
+)
+
+// Metric descriptors.
+var (
+	threadCacheSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, threadCache, "size"),
+		"The number of threads the server caches for reuse.",
+		nil, nil,
+	)
+	threadCacheThreadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, threadCache, "threads"),
+		"The number of threads in the thread cache.",
+		nil, nil,
+	)
+	threadCacheThreadsCreatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, threadCache, "threads_created_total"),
+		"Total number of threads created to handle connections.",
+		nil, nil,
+	)
+	threadCacheMissRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, threadCache, "miss_ratio"),
+		"Ratio of threads created to connection attempts since the server started.",
+		nil, nil,
+	)
+	threadCacheUsageRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, threadCache, "usage_ratio"),
+		"Ratio of cached threads to thread_cache_size.",
+		nil, nil,
+	)
+)
+
+// ScrapeThreadCache collects connection thread cache efficiency.
+func ScrapeThreadCache(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var size float64
+	if err := db.QueryRow(threadCacheSizeQuery).Scan(&size); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(threadCacheSizeDesc, prometheus.GaugeValue, size)
+
+	statusRows, err := db.Query(threadCacheStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key                          string
+		val                          sql.RawBytes
+		created, cached, connections float64
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Threads_created":
+			created = floatVal
+			ch <- prometheus.MustNewConstMetric(threadCacheThreadsCreatedDesc, prometheus.CounterValue, floatVal)
+		case "Threads_cached":
+			cached = floatVal
+			ch <- prometheus.MustNewConstMetric(threadCacheThreadsDesc, prometheus.GaugeValue, floatVal)
+		case "Connections":
+			connections = floatVal
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	if connections > 0 {
+		ch <- prometheus.MustNewConstMetric(threadCacheMissRatioDesc, prometheus.GaugeValue, created/connections)
+	}
+	if size > 0 {
+		ch <- prometheus.MustNewConstMetric(threadCacheUsageRatioDesc, prometheus.GaugeValue, cached/size)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeThreadCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(threadCacheSizeQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@thread_cache_size"}).AddRow(16))
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Connections", "2000").
+		AddRow("Threads_cached", "4").
+		AddRow("Threads_created", "500")
+	mock.ExpectQuery(sanitizeQuery(threadCacheStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeThreadCache(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 16, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 500, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
+
+func TestScrapeThreadCacheDisabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(threadCacheSizeQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"@@thread_cache_size"}).AddRow(0))
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Connections", "0").
+		AddRow("Threads_cached", "0").
+		AddRow("Threads_created", "0")
+	mock.ExpectQuery(sanitizeQuery(threadCacheStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeThreadCache(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No ratios without connections or cache", t, func() {
+		for m := range ch {
+			convey.So(m.Desc(), convey.ShouldNotEqual, threadCacheMissRatioDesc)
+			convey.So(m.Desc(), convey.ShouldNotEqual, threadCacheUsageRatioDesc)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
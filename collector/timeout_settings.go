@@ -0,0 +1,82 @@
+// Scrape server side timeout settings from `SHOW GLOBAL VARIABLES`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	timeout = "timeout"
+	// Query.
+	timeoutSettingsQuery = `
+		SHOW GLOBAL VARIABLES
+		  WHERE Variable_name IN (
+		    'max_execution_time', 'lock_wait_timeout', 'innodb_lock_wait_timeout',
+		    'wait_timeout', 'interactive_timeout', 'net_read_timeout', 'net_write_timeout'
+		  )
+		`
+)
+
+// Metric descriptors.
+var timeoutDescs = map[string]*prometheus.Desc{
+	"max_execution_time": newDesc(timeout, "max_execution_time_seconds",
+		"Execution timeout for SELECT statements, 0 if disabled."),
+	"lock_wait_timeout": newDesc(timeout, "lock_wait_timeout_seconds",
+		"Timeout to acquire metadata locks."),
+	"innodb_lock_wait_timeout": newDesc(timeout, "innodb_lock_wait_timeout_seconds",
+		"Timeout of an InnoDB transaction to wait for a row lock."),
+	"wait_timeout": newDesc(timeout, "wait_timeout_seconds",
+		"Timeout after which idle noninteractive connections are closed."),
+	"interactive_timeout": newDesc(timeout, "interactive_timeout_seconds",
+		"Timeout after which idle interactive connections are closed."),
+	"net_read_timeout": newDesc(timeout, "net_read_timeout_seconds",
+		"Timeout to wait for more data from a connection before aborting the read."),
+	"net_write_timeout": newDesc(timeout, "net_write_timeout_seconds",
+		"Timeout to wait for a block to be written to a connection before aborting the write."),
+}
+
+// timeoutScales converts the settings not in seconds to seconds.
+var timeoutScales = map[string]float64{
+	"max_execution_time": 1e-3,
+}
+
+// ScrapeTimeoutSettings collects server side timeout settings from
+// `SHOW GLOBAL VARIABLES`. Settings the server does not have, e.g.
+// max_execution_time before MySQL 5.7.8, are skipped.
+func ScrapeTimeoutSettings(db *sql.DB, ch chan<- prometheus.Metric) error {
+	timeoutRows, err := db.Query(timeoutSettingsQuery)
+	if err != nil {
+		return err
+	}
+	defer timeoutRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+
+	for timeoutRows.Next() {
+		if err := timeoutRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		key = strings.ToLower(key)
+		desc, ok := timeoutDescs[key]
+		if !ok {
+			continue
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		if scale, ok := timeoutScales[key]; ok {
+			floatVal *= scale
+		}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, floatVal)
+	}
+	return timeoutRows.Err()
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeTimeoutSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("innodb_lock_wait_timeout", "50").
+		AddRow("interactive_timeout", "28800").
+		AddRow("lock_wait_timeout", "31536000").
+		AddRow("max_execution_time", "2500").
+		AddRow("net_read_timeout", "30").
+		AddRow("net_write_timeout", "60").
+		AddRow("wait_timeout", "600")
+	mock.ExpectQuery(sanitizeQuery(timeoutSettingsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeTimeoutSettings(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []struct {
+		desc   *prometheus.Desc
+		result MetricResult
+	}{
+		{timeoutDescs["innodb_lock_wait_timeout"], MetricResult{labels: labelMap{}, value: 50, metricType: dto.MetricType_GAUGE}},
+		{timeoutDescs["interactive_timeout"], MetricResult{labels: labelMap{}, value: 28800, metricType: dto.MetricType_GAUGE}},
+		{timeoutDescs["lock_wait_timeout"], MetricResult{labels: labelMap{}, value: 31536000, metricType: dto.MetricType_GAUGE}},
+		{timeoutDescs["max_execution_time"], MetricResult{labels: labelMap{}, value: 2.5, metricType: dto.MetricType_GAUGE}},
+		{timeoutDescs["net_read_timeout"], MetricResult{labels: labelMap{}, value: 30, metricType: dto.MetricType_GAUGE}},
+		{timeoutDescs["net_write_timeout"], MetricResult{labels: labelMap{}, value: 60, metricType: dto.MetricType_GAUGE}},
+		{timeoutDescs["wait_timeout"], MetricResult{labels: labelMap{}, value: 600, metricType: dto.MetricType_GAUGE}},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			m := <-ch
+			convey.So(m.Desc(), convey.ShouldEqual, expect.desc)
+			convey.So(readMetric(m), convey.ShouldResemble, expect.result)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
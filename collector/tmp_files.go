@@ -0,0 +1,76 @@
+// Scrape temporary file and table creation from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Subsystem.
+	tmp = "tmp"
+	// Query.
+	tmpFilesStatusQuery = `
+		SHOW GLOBAL STATUS
+		  WHERE Variable_name IN ('Created_tmp_files', 'Created_tmp_tables', 'Created_tmp_disk_tables')
+		`
+)
+
+// Metric descriptors.
+var (
+	tmpFilesCreatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tmp, "files_created_total"),
+		"Total number of temporary files created by the server.",
+		nil, nil,
+	)
+	tmpDiskTablesRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tmp, "disk_tables_ratio"),
+		"Ratio of internal temporary tables created on disk to all internal temporary tables created.",
+		nil, nil,
+	)
+)
+
+// ScrapeTmpFiles collects the temporary files created and the share of
+// internal temporary tables that spilled to disk, which helps sizing
+// tmp_table_size and max_heap_table_size.
+func ScrapeTmpFiles(db *sql.DB, ch chan<- prometheus.Metric) error {
+	statusRows, err := db.Query(tmpFilesStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key                    string
+		val                    sql.RawBytes
+		tables, diskTables     float64
+		haveTables, haveOnDisk bool
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Created_tmp_files":
+			ch <- prometheus.MustNewConstMetric(tmpFilesCreatedDesc, prometheus.CounterValue, floatVal)
+		case "Created_tmp_tables":
+			tables, haveTables = floatVal, true
+		case "Created_tmp_disk_tables":
+			diskTables, haveOnDisk = floatVal, true
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	if haveTables && haveOnDisk && tables > 0 {
+		ch <- prometheus.MustNewConstMetric(tmpDiskTablesRatioDesc, prometheus.GaugeValue, diskTables/tables)
+	}
+	return nil
+}
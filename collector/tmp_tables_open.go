@@ -0,0 +1,60 @@
+// Scrape the number of open tables from `SHOW GLOBAL STATUS` and of open
+// temporary tables from `information_schema.innodb_temp_table_info`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	openTablesStatusQuery = `SHOW GLOBAL STATUS LIKE 'Open_tables'`
+	// Only user created InnoDB temporary tables are listed, internal
+	// temporary tables are not.
+	tmpTablesOpenQuery = `SELECT COUNT(*) FROM information_schema.innodb_temp_table_info`
+)
+
+// Metric descriptors.
+var (
+	openTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "open_tables"),
+		"The number of tables that are open.",
+		nil, nil,
+	)
+	tmpTablesOpenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tmp, "tables_open"),
+		"The number of user created InnoDB temporary tables that are open, steadily growing when clients do not drop their temporary tables.",
+		nil, nil,
+	)
+)
+
+// ScrapeOpenTempTables collects the number of open tables along with the
+// number of open temporary tables, available from MySQL 5.7.
+func ScrapeOpenTempTables(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var (
+		key        string
+		openTables float64
+	)
+	if err := db.QueryRow(openTablesStatusQuery).Scan(&key, &openTables); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(openTablesDesc, prometheus.GaugeValue, openTables)
+
+	exists, err := tableExists(db, "information_schema", "INNODB_TEMP_TABLE_INFO")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Debugln("information_schema.innodb_temp_table_info is not available.")
+		return nil
+	}
+	var count float64
+	if err := db.QueryRow(tmpTablesOpenQuery).Scan(&count); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(tmpTablesOpenDesc, prometheus.GaugeValue, count)
+	return nil
+}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeOpenTempTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(openTablesStatusQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).AddRow("Open_tables", "412"))
+	mock.ExpectQuery(sanitizeQuery(tableExistsQuery)).
+		WithArgs("information_schema", "INNODB_TEMP_TABLE_INFO").
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(1))
+	mock.ExpectQuery(sanitizeQuery(tmpTablesOpenQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(37))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = ScrapeOpenTempTables(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 412, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 37, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Scrape how warm the server is after a restart from `SHOW GLOBAL STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const warmthStatusQuery = `
+	SHOW GLOBAL STATUS
+	  WHERE Variable_name IN ('Uptime', 'Innodb_buffer_pool_pages_data', 'Innodb_buffer_pool_pages_total')
+	`
+
+// Metric descriptors.
+var (
+	bufferPoolFillRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "buffer_pool_fill_ratio"),
+		"Ratio of InnoDB buffer pool pages holding data to all pages, low while the buffer pool warms up.",
+		nil, nil,
+	)
+	uptimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "uptime_seconds"),
+		"Number of seconds since the server started.",
+		nil, nil,
+	)
+)
+
+// ScrapeWarmthState collects the buffer pool fill ratio along with the uptime,
+// so that alerts can be held back while a restarted server warms up.
+func ScrapeWarmthState(db *sql.DB, ch chan<- prometheus.Metric) error {
+	statusRows, err := db.Query(warmthStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer statusRows.Close()
+
+	var (
+		key                   string
+		val                   sql.RawBytes
+		pagesData, pagesTotal float64
+	)
+	for statusRows.Next() {
+		if err := statusRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		floatVal, ok := parseStatus(val)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Uptime":
+			ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, floatVal)
+		case "Innodb_buffer_pool_pages_data":
+			pagesData = floatVal
+		case "Innodb_buffer_pool_pages_total":
+			pagesTotal = floatVal
+		}
+	}
+	if err := statusRows.Err(); err != nil {
+		return err
+	}
+
+	if pagesTotal > 0 {
+		ch <- prometheus.MustNewConstMetric(bufferPoolFillRatioDesc, prometheus.GaugeValue, pagesData/pagesTotal)
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"path"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -121,6 +123,334 @@ var (
 		"collect.engine_innodb_status",
 		"Collect from SHOW ENGINE INNODB STATUS",
 	).Default("false").Bool()
+	collectAuditLog = kingpin.Flag(
+		"collect.audit_log",
+		"Collect audit log plugin metrics from SHOW GLOBAL STATUS",
+	).Default("false").Bool()
+	collectSchemaSize = kingpin.Flag(
+		"collect.info_schema.schema_size",
+		"Collect per-schema data and index size rollups from information_schema.tables",
+	).Default("false").Bool()
+	collectPerfApplierByWorker = kingpin.Flag(
+		"collect.perf_schema.replication_applier_status_by_worker",
+		"Collect metrics from performance_schema.replication_applier_status_by_worker",
+	).Default("false").Bool()
+	collectPerfStatusByAccount = kingpin.Flag(
+		"collect.perf_schema.status_by_account",
+		"Collect status variables per account from performance_schema.status_by_account",
+	).Default("false").Bool()
+	collectHostname = kingpin.Flag(
+		"collect.hostname",
+		"Collect the server hostname from @@hostname as mysql_hostname_info",
+	).Default("false").Bool()
+	collectInnodbBufferPoolDump = kingpin.Flag(
+		"collect.innodb_buffer_pool_dump",
+		"Collect InnoDB buffer pool dump/load progress",
+	).Default("false").Bool()
+	collectPerfThreadCPU = kingpin.Flag(
+		"collect.perf_schema.thread_cpu",
+		"Collect CPU time per user from performance_schema.threads",
+	).Default("false").Bool()
+	collectStatusLike = kingpin.Flag(
+		"collect.global_status_like",
+		"Collect status variables matching collect.global_status_like.pattern, instead of the full SHOW GLOBAL STATUS",
+	).Default("false").Bool()
+	collectStatusLikePatterns = kingpin.Flag(
+		"collect.global_status_like.pattern",
+		"LIKE pattern of status variables to collect with collect.global_status_like, can be repeated",
+	).Strings()
+	collectRelayLog = kingpin.Flag(
+		"collect.relay_log",
+		"Collect relay log space usage and limits from SHOW SLAVE STATUS",
+	).Default("false").Bool()
+	collectInnodbLockTimeouts = kingpin.Flag(
+		"collect.engine_innodb_lock_timeouts",
+		"Collect InnoDB row lock and metadata lock waits along with estimated lock wait timeouts",
+	).Default("false").Bool()
+	collectInnodbFTS = kingpin.Flag(
+		"collect.info_schema.innodb_ft",
+		"Collect FULLTEXT index stats from information_schema.innodb_ft_* for @@innodb_ft_aux_table",
+	).Default("false").Bool()
+	collectLongTransactions = kingpin.Flag(
+		"collect.info_schema.long_transactions",
+		"Collect the oldest running transactions from information_schema.innodb_trx",
+	).Default("false").Bool()
+	collectTempTablespaces = kingpin.Flag(
+		"collect.info_schema.innodb_temp_tablespaces",
+		"Collect session temporary tablespace usage from information_schema.innodb_session_temp_tablespaces",
+	).Default("false").Bool()
+	collectPerfReplConnStatus = kingpin.Flag(
+		"collect.perf_schema.replication_connection_status",
+		"Collect from performance_schema.replication_connection_status",
+	).Default("false").Bool()
+	collectVariableDrift = kingpin.Flag(
+		"collect.perf_schema.variable_drift",
+		"Collect drift between running and persisted variables from performance_schema.persisted_variables",
+	).Default("false").Bool()
+	collectPerfWaitsByInstance = kingpin.Flag(
+		"collect.perf_schema.waits_by_instance",
+		"Collect the instances with the most wait time from performance_schema.events_waits_summary_by_instance",
+	).Default("false").Bool()
+	collectInnodbPageOps = kingpin.Flag(
+		"collect.info_schema.innodb_page_ops",
+		"Collect InnoDB index page splits and merges from information_schema.innodb_metrics",
+	).Default("false").Bool()
+	collectPerfSortTmpByAccount = kingpin.Flag(
+		"collect.perf_schema.sort_tmp_by_account",
+		"Collect temporary table and sort usage by user from performance_schema.events_statements_summary_by_account_by_event_name",
+	).Default("false").Bool()
+	collectPerfVariablesInfo = kingpin.Flag(
+		"collect.perf_schema.variables_info",
+		"Collect where non-default variables were set from performance_schema.variables_info",
+	).Default("false").Bool()
+	collectDDLProgress = kingpin.Flag(
+		"collect.perf_schema.ddl_progress",
+		"Collect the progress of running InnoDB ALTER TABLE statements from performance_schema.events_stages_current",
+	).Default("false").Bool()
+	collectNetworkStats = kingpin.Flag(
+		"collect.network",
+		"Collect network bytes, connection and abort counters from SHOW GLOBAL STATUS",
+	).Default("false").Bool()
+	collectInnodbLogIO = kingpin.Flag(
+		"collect.innodb_log_io",
+		"Collect InnoDB redo log write and fsync counters from SHOW GLOBAL STATUS",
+	).Default("false").Bool()
+	collectCharsetInventory = kingpin.Flag(
+		"collect.info_schema.charset_inventory",
+		"Collect table and column counts by character set and collation from information_schema",
+	).Default("false").Bool()
+	collectDurabilitySettings = kingpin.Flag(
+		"collect.durability",
+		"Collect durability related settings such as sync_binlog and innodb_flush_log_at_trx_commit",
+	).Default("false").Bool()
+	collectUndoTablespaces = kingpin.Flag(
+		"collect.info_schema.innodb_undo_tablespaces",
+		"Collect InnoDB undo tablespace truncation activity and sizes",
+	).Default("false").Bool()
+	collectCanary = kingpin.Flag(
+		"collect.canary",
+		"Measure replication propagation latency through a canary table",
+	).Default("false").Bool()
+	collectCanaryDatabase = kingpin.Flag(
+		"collect.canary.database",
+		"Database of the canary table",
+	).Default("heartbeat").String()
+	collectCanaryTable = kingpin.Flag(
+		"collect.canary.table",
+		"Canary table used to measure replication propagation latency",
+	).Default("canary").String()
+	collectCanaryRole = kingpin.Flag(
+		"collect.canary.role",
+		"Whether to read the canary table (replica) or write it (primary), writes are opt-in",
+	).Default(collector.CanaryRoleRead).Enum(collector.CanaryRoleRead, collector.CanaryRoleWrite)
+	collectSSLCiphers = kingpin.Flag(
+		"collect.perf_schema.ssl_ciphers",
+		"Collect the TLS ciphers of current connections from performance_schema.status_by_thread",
+	).Default("false").Bool()
+	collectGTIDGap = kingpin.Flag(
+		"collect.slave_gtid_gap",
+		"Collect the number of transactions the replica is behind the primary from their GTID sets",
+	).Default("false").Bool()
+	collectDigestSamples = kingpin.Flag(
+		"collect.perf_schema.digest_samples",
+		"Collect sample statements of the slowest digests from performance_schema.events_statements_summary_by_digest",
+	).Default("false").Bool()
+	collectConnectionWatermark = kingpin.Flag(
+		"collect.connection_watermark",
+		"Collect the peak connection usage relative to max_connections and when it was reached",
+	).Default("false").Bool()
+	collectTableOpenCache = kingpin.Flag(
+		"collect.table_open_cache",
+		"Collect table open cache hits, misses and overflows",
+	).Default("false").Bool()
+	collectInnodbCheckpoint = kingpin.Flag(
+		"collect.innodb_checkpoint",
+		"Collect the InnoDB checkpoint age relative to the synchronous flush point",
+	).Default("false").Bool()
+	collectThreadCache = kingpin.Flag(
+		"collect.thread_cache",
+		"Collect connection thread cache hits and usage",
+	).Default("false").Bool()
+	collectReplicationLoop = kingpin.Flag(
+		"collect.slave_loop",
+		"Collect the server ids of the server and its sources to detect replication loops",
+	).Default("false").Bool()
+	collectTmpFiles = kingpin.Flag(
+		"collect.tmp_files",
+		"Collect temporary file creation and on-disk temporary table ratio",
+	).Default("false").Bool()
+	collectMysqlX = kingpin.Flag(
+		"collect.mysqlx",
+		"Collect X Plugin status variables",
+	).Default("false").Bool()
+	collectConnectionLimitHits = kingpin.Flag(
+		"collect.perf_schema.connection_limit_hits",
+		"Collect account resource limit hits by user from performance_schema.events_errors_summary_by_account_by_error",
+	).Default("false").Bool()
+	collectErrorSummary = kingpin.Flag(
+		"collect.perf_schema.error_summary",
+		"Collect the errors raised most often from performance_schema.events_errors_summary_global_by_error",
+	).Default("false").Bool()
+	collectAutoIncrementSummary = kingpin.Flag(
+		"collect.auto_increment.summary",
+		"Collect the highest auto_increment fill ratio of each schema",
+	).Default("false").Bool()
+	collectWarmthState = kingpin.Flag(
+		"collect.warmth",
+		"Collect the buffer pool fill ratio and uptime to tell a warming up server",
+	).Default("false").Bool()
+	collectCommitOrderWaits = kingpin.Flag(
+		"collect.perf_schema.commit_order_waits",
+		"Collect the commit order waits of replication workers by channel from performance_schema",
+	).Default("false").Bool()
+	collectLockErrorsByUser = kingpin.Flag(
+		"collect.perf_schema.lock_errors_by_user",
+		"Collect deadlocks and lock wait timeouts by user from performance_schema.events_errors_summary_by_account_by_error",
+	).Default("false").Bool()
+	collectBinlogCompression = kingpin.Flag(
+		"collect.binlog_compression",
+		"Collect binary log transaction compression statistics from performance_schema",
+	).Default("false").Bool()
+	collectUnappliedTransactions = kingpin.Flag(
+		"collect.slave_unapplied_transactions",
+		"Collect the number of received but not yet applied transactions by replication channel",
+	).Default("false").Bool()
+	collectPreparedStmtCache = kingpin.Flag(
+		"collect.prepared_stmt",
+		"Collect prepared statement usage against max_prepared_stmt_count",
+	).Default("false").Bool()
+	collectStatementMix = kingpin.Flag(
+		"collect.statement_mix",
+		"Collect the number of select, insert, update, delete and other statements",
+	).Default("false").Bool()
+	collectDigestTmpTables = kingpin.Flag(
+		"collect.perf_schema.digest_tmp_tables",
+		"Collect the digests creating the most on-disk temporary tables and sort merge passes from performance_schema.events_statements_summary_by_digest",
+	).Default("false").Bool()
+	collectQueryRewrite = kingpin.Flag(
+		"collect.query_rewrite",
+		"Collect query rewrite plugin status variables",
+	).Default("false").Bool()
+	collectProtocolCompression = kingpin.Flag(
+		"collect.perf_schema.protocol_compression",
+		"Collect the number of connections using protocol compression from performance_schema.status_by_thread",
+	).Default("false").Bool()
+	collectTableAccessRatio = kingpin.Flag(
+		"collect.perf_schema.table_access_ratio",
+		"Collect the read ratio of the tables with the most I/O from performance_schema.table_io_waits_summary_by_table",
+	).Default("false").Bool()
+	collectInnodbFlush = kingpin.Flag(
+		"collect.innodb_flush",
+		"Collect the pages flushed by adaptive, LRU and background flushing from information_schema.innodb_metrics",
+	).Default("false").Bool()
+	collectReplicationConfig = kingpin.Flag(
+		"collect.slave_source_info",
+		"Collect the source host and port each replication channel replicates from",
+	).Default("false").Bool()
+	collectMyISAMKeyCache = kingpin.Flag(
+		"collect.myisam_key_cache",
+		"Collect MyISAM key cache utilization and write hit ratio",
+	).Default("false").Bool()
+	collectIsolationLevels = kingpin.Flag(
+		"collect.isolation_levels",
+		"Collect the default transaction isolation level and the number of sessions by isolation level",
+	).Default("false").Bool()
+	collectInnodbDoublewrite = kingpin.Flag(
+		"collect.innodb_doublewrite",
+		"Collect InnoDB doublewrite buffer activity",
+	).Default("false").Bool()
+	collectStatementHistogram = kingpin.Flag(
+		"collect.perf_schema.statement_histogram",
+		"Collect the statement latency histogram from performance_schema.events_statements_histogram_global",
+	).Default("false").Bool()
+	collectAccounts = kingpin.Flag(
+		"collect.perf_schema.accounts",
+		"Collect current and total connections by account from performance_schema.accounts",
+	).Default("false").Bool()
+	collectServerTime = kingpin.Flag(
+		"collect.server_time",
+		"Collect the current time of the server clock",
+	).Default("true").Bool()
+	collectInnodbBufferPoolConfig = kingpin.Flag(
+		"collect.innodb_buffer_pool_config",
+		"Collect InnoDB buffer pool sizing and online resize progress",
+	).Default("false").Bool()
+	collectAppliedTransactions = kingpin.Flag(
+		"collect.slave_applied_transactions",
+		"Collect the transactions applied by each replication channel",
+	).Default("false").Bool()
+	collectOpenTempTables = kingpin.Flag(
+		"collect.tmp_tables_open",
+		"Collect the number of open tables and open temporary tables",
+	).Default("false").Bool()
+	collectTimeoutSettings = kingpin.Flag(
+		"collect.timeout_settings",
+		"Collect server side timeout settings such as wait_timeout and innodb_lock_wait_timeout",
+	).Default("false").Bool()
+	collectSchemaCacheRisk = kingpin.Flag(
+		"collect.info_schema.table_cache_risk",
+		"Collect table counts by schema alongside table_open_cache and table_definition_cache",
+	).Default("false").Bool()
+	collectApplierIdle = kingpin.Flag(
+		"collect.slave_applier_idle",
+		"Collect how long the replication applier has been idle",
+	).Default("false").Bool()
+	collectInnodbFsyncLatency = kingpin.Flag(
+		"collect.innodb_fsync_latency",
+		"Collect the latency of the syncs and other miscellaneous operations of the InnoDB redo log and data files from performance_schema.file_summary_by_event_name",
+	).Default("false").Bool()
+	collectGTIDIntervals = kingpin.Flag(
+		"collect.gtid_intervals",
+		"Collect the intervals of gtid_executed by source UUID to detect holes",
+	).Default("false").Bool()
+	collectThreadMemory = kingpin.Flag(
+		"collect.perf_schema.thread_memory",
+		"Collect the memory used by the threads of each user from performance_schema.memory_summary_by_thread_by_event_name",
+	).Default("false").Bool()
+	collectMemoryLimits = kingpin.Flag(
+		"collect.memory_limits",
+		"Collect the memory the buffer pool and connections may use and the global connection memory against its limit",
+	).Default("false").Bool()
+	collectReplicaHealth = kingpin.Flag(
+		"collect.slave_health",
+		"Collect a replica health score for load balancers",
+	).Default("false").Bool()
+	collectRoutineStats = kingpin.Flag(
+		"collect.perf_schema.routine_stats",
+		"Collect stored program execution statistics from performance_schema.events_statements_summary_by_program",
+	).Default("false").Bool()
+	collectListenerBacklog = kingpin.Flag(
+		"collect.listener_backlog",
+		"Collect back_log along with the errors accepting connections, unless collect.global_status or collect.global_status_like report them",
+	).Default("false").Bool()
+	collectParallelReplicationConfig = kingpin.Flag(
+		"collect.slave_parallel_config",
+		"Collect the multi-threaded replication settings",
+	).Default("false").Bool()
+	collectInnodbStats = kingpin.Flag(
+		"collect.innodb_stats",
+		"Collect InnoDB persistent statistics settings and the age of the statistics of each table",
+	).Default("false").Bool()
+	collectInnodbTrxMix = kingpin.Flag(
+		"collect.innodb_trx_mix",
+		"Collect read-only and read-write InnoDB transaction commits from information_schema.innodb_metrics",
+	).Default("false").Bool()
+	collectInnodbAHIBenefit = kingpin.Flag(
+		"collect.innodb_ahi_benefit",
+		"Collect an advisory adaptive hash index benefit ratio from SHOW ENGINE INNODB STATUS",
+	).Default("false").Bool()
+	collectReplicaDelayConfig = kingpin.Flag(
+		"collect.slave_delay_config",
+		"Collect the configured delay of each replication channel from performance_schema.replication_applier_configuration",
+	).Default("false").Bool()
+	collectPerfSchemaLost = kingpin.Flag(
+		"collect.perf_schema.lost",
+		"Collect the instrumentation dropped by performance_schema from the Performance_schema_*_lost status variables, when neither collect.global_status nor collect.global_status_like is enabled",
+	).Default("false").Bool()
+	collectOSCInProgress = kingpin.Flag(
+		"collect.info_schema.online_schema_change",
+		"Collect the tables created by in-progress online schema changes, such as gh-ost and pt-online-schema-change, from information_schema.tables",
+	).Default("false").Bool()
 	collectHeartbeat = kingpin.Flag(
 		"collect.heartbeat",
 		"Collect from heartbeat",
@@ -137,7 +467,45 @@ var (
 		"mysql.max.connection",
 		"Maximum connection pool size to MySQL server (max value 64)",
 	).Default("8").Int()
-	dsn string
+	mysqlMaxIdleConns = kingpin.Flag(
+		"mysql.max-idle-connections",
+		"Maximum idle connections kept in the pool to MySQL server, 0 for one per enabled collector",
+	).Default("0").Int()
+	mysqlConnMaxLifetime = kingpin.Flag(
+		"mysql.conn-max-lifetime",
+		"Maximum amount of time a connection to MySQL server may be reused",
+	).Default("2m").Duration()
+	mysqlConnMaxIdleTime = kingpin.Flag(
+		"mysql.conn-max-idle-time",
+		"Maximum amount of time a connection to MySQL server may be idle, 0 for no limit",
+	).Default("0").Duration()
+	maxMetricsPerCollector = kingpin.Flag(
+		"exporter.max-metrics-per-collector",
+		"Maximum number of metrics a single collector may emit per scrape, 0 for unlimited",
+	).Default("0").Int()
+	strictCollectors = kingpin.Flag(
+		"exporter.strict-collectors",
+		"Discard the metrics of a collector that fails instead of exposing its partial results",
+	).Default("false").Bool()
+	database = kingpin.Flag(
+		"exporter.database",
+		"Only collect the tables of this database in the schema scanning collectors, checked at startup",
+	).Default("").String()
+	socks5Proxy = kingpin.Flag(
+		"exporter.socks5-proxy",
+		"Connect to MySQL over TCP through the SOCKS5 proxy at this host:port",
+	).Default("").String()
+	constLabels = kingpin.Flag(
+		"exporter.const-label",
+		"Constant label added to every MySQL metric as name=value, may be repeated",
+	).StringMap()
+	refreshIntervalFlags = kingpin.Flag(
+		"exporter.refresh-interval",
+		"Refresh a collector in the background every interval as collector=interval, e.g. info_schema.tables=5m, may be repeated",
+	).StringMap()
+	refreshIntervals map[string]time.Duration
+	dsn              string
+	gtidPrimaryDSN   string
 )
 
 // landingPage contains the HTML served at '/'.
@@ -147,6 +515,7 @@ var landingPage = []byte(`<html>
 <body>
 <h1>MySQLd exporter</h1>
 <p><a href='` + *metricPath + `'>Metrics</a></p>
+<p><a href='/health'>Health</a></p>
 </body>
 </html>
 `)
@@ -178,6 +547,26 @@ func init() {
 	prometheus.MustRegister(version.NewCollector("mysqld_exporter"))
 }
 
+// parseRefreshIntervals parses the --exporter.refresh-interval flags, keyed by
+// collector name as in collect[], into Collect.RefreshIntervals.
+func parseRefreshIntervals(flags map[string]string) (map[string]time.Duration, error) {
+	intervals := make(map[string]time.Duration, len(flags))
+	for name, value := range flags {
+		if f := kingpin.CommandLine.GetFlag("collect." + name); f == nil || !f.Model().IsBoolFlag() {
+			return nil, fmt.Errorf("unknown collector %s in refresh interval", name)
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid refresh interval for %s: %s", name, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("refresh interval for %s must be positive", name)
+		}
+		intervals["collect."+name] = interval
+	}
+	return intervals, nil
+}
+
 func filter(filters map[string]bool, name string, flag bool) bool {
 	if len(filters) > 0 {
 		return flag && filters[name]
@@ -198,37 +587,131 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	collect := collector.Collect{
-		SlowLogFilter:        *slowLogFilter,
-		Processlist:          filter(filters, "info_schema.processlist", *collectProcesslist),
-		TableSchema:          filter(filters, "info_schema.tables", *collectTableSchema),
-		InnodbTablespaces:    filter(filters, "info_schema.innodb_tablespaces", *collectInnodbTablespaces),
-		InnodbMetrics:        filter(filters, "info_schema.innodb_metrics", *collectInnodbMetrics),
-		GlobalStatus:         filter(filters, "global_status", *collectGlobalStatus),
-		GlobalVariables:      filter(filters, "global_variables", *collectGlobalVariables),
-		SlaveStatus:          filter(filters, "slave_status", *collectSlaveStatus),
-		AutoIncrementColumns: filter(filters, "auto_increment.columns", *collectAutoIncrementColumns),
-		BinlogSize:           filter(filters, "binlog_size", *collectBinlogSize),
-		PerfTableIOWaits:     filter(filters, "perf_schema.tableiowaits", *collectPerfTableIOWaits),
-		PerfIndexIOWaits:     filter(filters, "perf_schema.indexiowaits", *collectPerfIndexIOWaits),
-		PerfTableLockWaits:   filter(filters, "perf_schema.tablelocks", *collectPerfTableLockWaits),
-		PerfEventsStatements: filter(filters, "perf_schema.eventsstatements", *collectPerfEventsStatements),
-		PerfEventsWaits:      filter(filters, "perf_schema.eventswaits", *collectPerfEventsWaits),
-		PerfFileEvents:       filter(filters, "perf_schema.file_events", *collectPerfFileEvents),
-		PerfFileInstances:    filter(filters, "perf_schema.file_instances", *collectPerfFileInstances),
-		UserStat:             filter(filters, "info_schema.userstats", *collectUserStat),
-		ClientStat:           filter(filters, "info_schema.clientstats", *collectClientStat),
-		TableStat:            filter(filters, "info_schema.tablestats", *collectTableStat),
-		QueryResponseTime:    filter(filters, "info_schema.query_response_time", *collectQueryResponseTime),
-		EngineTokudbStatus:   filter(filters, "engine_tokudb_status", *collectEngineTokudbStatus),
-		EngineInnodbStatus:   filter(filters, "engine_innodb_status", *collectEngineInnodbStatus),
-		Heartbeat:            filter(filters, "heartbeat", *collectHeartbeat),
-		HeartbeatDatabase:    *collectHeartbeatDatabase,
-		HeartbeatTable:       *collectHeartbeatTable,
-		MaxMySQLConns:        *mysqlMaxconns,
+		SlowLogFilter:             *slowLogFilter,
+		Processlist:               filter(filters, "info_schema.processlist", *collectProcesslist),
+		TableSchema:               filter(filters, "info_schema.tables", *collectTableSchema),
+		InnodbTablespaces:         filter(filters, "info_schema.innodb_tablespaces", *collectInnodbTablespaces),
+		InnodbMetrics:             filter(filters, "info_schema.innodb_metrics", *collectInnodbMetrics),
+		GlobalStatus:              filter(filters, "global_status", *collectGlobalStatus),
+		GlobalVariables:           filter(filters, "global_variables", *collectGlobalVariables),
+		SlaveStatus:               filter(filters, "slave_status", *collectSlaveStatus),
+		AutoIncrementColumns:      filter(filters, "auto_increment.columns", *collectAutoIncrementColumns),
+		BinlogSize:                filter(filters, "binlog_size", *collectBinlogSize),
+		PerfTableIOWaits:          filter(filters, "perf_schema.tableiowaits", *collectPerfTableIOWaits),
+		PerfIndexIOWaits:          filter(filters, "perf_schema.indexiowaits", *collectPerfIndexIOWaits),
+		PerfTableLockWaits:        filter(filters, "perf_schema.tablelocks", *collectPerfTableLockWaits),
+		PerfEventsStatements:      filter(filters, "perf_schema.eventsstatements", *collectPerfEventsStatements),
+		PerfEventsWaits:           filter(filters, "perf_schema.eventswaits", *collectPerfEventsWaits),
+		PerfFileEvents:            filter(filters, "perf_schema.file_events", *collectPerfFileEvents),
+		PerfFileInstances:         filter(filters, "perf_schema.file_instances", *collectPerfFileInstances),
+		UserStat:                  filter(filters, "info_schema.userstats", *collectUserStat),
+		ClientStat:                filter(filters, "info_schema.clientstats", *collectClientStat),
+		TableStat:                 filter(filters, "info_schema.tablestats", *collectTableStat),
+		QueryResponseTime:         filter(filters, "info_schema.query_response_time", *collectQueryResponseTime),
+		EngineTokudbStatus:        filter(filters, "engine_tokudb_status", *collectEngineTokudbStatus),
+		EngineInnodbStatus:        filter(filters, "engine_innodb_status", *collectEngineInnodbStatus),
+		AuditLog:                  filter(filters, "audit_log", *collectAuditLog),
+		SchemaSize:                filter(filters, "info_schema.schema_size", *collectSchemaSize),
+		PerfApplierByWorker:       filter(filters, "perf_schema.replication_applier_status_by_worker", *collectPerfApplierByWorker),
+		PerfStatusByAccount:       filter(filters, "perf_schema.status_by_account", *collectPerfStatusByAccount),
+		Hostname:                  filter(filters, "hostname", *collectHostname),
+		InnodbBufferPoolDump:      filter(filters, "innodb_buffer_pool_dump", *collectInnodbBufferPoolDump),
+		PerfThreadCPU:             filter(filters, "perf_schema.thread_cpu", *collectPerfThreadCPU),
+		StatusLike:                filter(filters, "global_status_like", *collectStatusLike),
+		RelayLog:                  filter(filters, "relay_log", *collectRelayLog),
+		InnodbLockTimeouts:        filter(filters, "engine_innodb_lock_timeouts", *collectInnodbLockTimeouts),
+		InnodbFTS:                 filter(filters, "info_schema.innodb_ft", *collectInnodbFTS),
+		LongTransactions:          filter(filters, "info_schema.long_transactions", *collectLongTransactions),
+		TempTablespaces:           filter(filters, "info_schema.innodb_temp_tablespaces", *collectTempTablespaces),
+		PerfReplConnStatus:        filter(filters, "perf_schema.replication_connection_status", *collectPerfReplConnStatus),
+		VariableDrift:             filter(filters, "perf_schema.variable_drift", *collectVariableDrift),
+		PerfWaitsByInstance:       filter(filters, "perf_schema.waits_by_instance", *collectPerfWaitsByInstance),
+		InnodbPageOps:             filter(filters, "info_schema.innodb_page_ops", *collectInnodbPageOps),
+		PerfSortTmpByAccount:      filter(filters, "perf_schema.sort_tmp_by_account", *collectPerfSortTmpByAccount),
+		PerfVariablesInfo:         filter(filters, "perf_schema.variables_info", *collectPerfVariablesInfo),
+		DDLProgress:               filter(filters, "perf_schema.ddl_progress", *collectDDLProgress),
+		NetworkStats:              filter(filters, "network", *collectNetworkStats),
+		InnodbLogIO:               filter(filters, "innodb_log_io", *collectInnodbLogIO),
+		CharsetInventory:          filter(filters, "info_schema.charset_inventory", *collectCharsetInventory),
+		DurabilitySettings:        filter(filters, "durability", *collectDurabilitySettings),
+		UndoTablespaces:           filter(filters, "info_schema.innodb_undo_tablespaces", *collectUndoTablespaces),
+		Canary:                    filter(filters, "canary", *collectCanary),
+		SSLCiphers:                filter(filters, "perf_schema.ssl_ciphers", *collectSSLCiphers),
+		GTIDGap:                   filter(filters, "slave_gtid_gap", *collectGTIDGap),
+		DigestSamples:             filter(filters, "perf_schema.digest_samples", *collectDigestSamples),
+		ConnectionWatermark:       filter(filters, "connection_watermark", *collectConnectionWatermark),
+		TableOpenCache:            filter(filters, "table_open_cache", *collectTableOpenCache),
+		InnodbCheckpoint:          filter(filters, "innodb_checkpoint", *collectInnodbCheckpoint),
+		ThreadCache:               filter(filters, "thread_cache", *collectThreadCache),
+		ReplicationLoop:           filter(filters, "slave_loop", *collectReplicationLoop),
+		TmpFiles:                  filter(filters, "tmp_files", *collectTmpFiles),
+		MysqlX:                    filter(filters, "mysqlx", *collectMysqlX),
+		ConnectionLimitHits:       filter(filters, "perf_schema.connection_limit_hits", *collectConnectionLimitHits),
+		ErrorSummary:              filter(filters, "perf_schema.error_summary", *collectErrorSummary),
+		AutoIncrementSummary:      filter(filters, "auto_increment.summary", *collectAutoIncrementSummary),
+		WarmthState:               filter(filters, "warmth", *collectWarmthState),
+		CommitOrderWaits:          filter(filters, "perf_schema.commit_order_waits", *collectCommitOrderWaits),
+		LockErrorsByUser:          filter(filters, "perf_schema.lock_errors_by_user", *collectLockErrorsByUser),
+		BinlogCompression:         filter(filters, "binlog_compression", *collectBinlogCompression),
+		UnappliedTransactions:     filter(filters, "slave_unapplied_transactions", *collectUnappliedTransactions),
+		PreparedStmtCache:         filter(filters, "prepared_stmt", *collectPreparedStmtCache),
+		StatementMix:              filter(filters, "statement_mix", *collectStatementMix),
+		DigestTmpTables:           filter(filters, "perf_schema.digest_tmp_tables", *collectDigestTmpTables),
+		QueryRewrite:              filter(filters, "query_rewrite", *collectQueryRewrite),
+		ProtocolCompression:       filter(filters, "perf_schema.protocol_compression", *collectProtocolCompression),
+		TableAccessRatio:          filter(filters, "perf_schema.table_access_ratio", *collectTableAccessRatio),
+		InnodbFlush:               filter(filters, "innodb_flush", *collectInnodbFlush),
+		ReplicationConfig:         filter(filters, "slave_source_info", *collectReplicationConfig),
+		MyISAMKeyCache:            filter(filters, "myisam_key_cache", *collectMyISAMKeyCache),
+		IsolationLevels:           filter(filters, "isolation_levels", *collectIsolationLevels),
+		InnodbDoublewrite:         filter(filters, "innodb_doublewrite", *collectInnodbDoublewrite),
+		StatementHistogram:        filter(filters, "perf_schema.statement_histogram", *collectStatementHistogram),
+		Accounts:                  filter(filters, "perf_schema.accounts", *collectAccounts),
+		ServerTime:                filter(filters, "server_time", *collectServerTime),
+		InnodbBufferPoolConfig:    filter(filters, "innodb_buffer_pool_config", *collectInnodbBufferPoolConfig),
+		AppliedTransactions:       filter(filters, "slave_applied_transactions", *collectAppliedTransactions),
+		OpenTempTables:            filter(filters, "tmp_tables_open", *collectOpenTempTables),
+		TimeoutSettings:           filter(filters, "timeout_settings", *collectTimeoutSettings),
+		SchemaCacheRisk:           filter(filters, "info_schema.table_cache_risk", *collectSchemaCacheRisk),
+		ApplierIdle:               filter(filters, "slave_applier_idle", *collectApplierIdle),
+		InnodbFsyncLatency:        filter(filters, "innodb_fsync_latency", *collectInnodbFsyncLatency),
+		GTIDIntervals:             filter(filters, "gtid_intervals", *collectGTIDIntervals),
+		ThreadMemory:              filter(filters, "perf_schema.thread_memory", *collectThreadMemory),
+		MemoryLimits:              filter(filters, "memory_limits", *collectMemoryLimits),
+		ReplicaHealth:             filter(filters, "slave_health", *collectReplicaHealth),
+		RoutineStats:              filter(filters, "perf_schema.routine_stats", *collectRoutineStats),
+		ListenerBacklog:           filter(filters, "listener_backlog", *collectListenerBacklog),
+		ParallelReplicationConfig: filter(filters, "slave_parallel_config", *collectParallelReplicationConfig),
+		InnodbStats:               filter(filters, "innodb_stats", *collectInnodbStats),
+		InnodbTrxMix:              filter(filters, "innodb_trx_mix", *collectInnodbTrxMix),
+		InnodbAHIBenefit:          filter(filters, "innodb_ahi_benefit", *collectInnodbAHIBenefit),
+		ReplicaDelayConfig:        filter(filters, "slave_delay_config", *collectReplicaDelayConfig),
+		PerfSchemaLost:            filter(filters, "perf_schema.lost", *collectPerfSchemaLost),
+		OSCInProgress:             filter(filters, "info_schema.online_schema_change", *collectOSCInProgress),
+		Heartbeat:                 filter(filters, "heartbeat", *collectHeartbeat),
+		HeartbeatDatabase:         *collectHeartbeatDatabase,
+		HeartbeatTable:            *collectHeartbeatTable,
+		CanaryDatabase:            *collectCanaryDatabase,
+		CanaryTable:               *collectCanaryTable,
+		CanaryRole:                *collectCanaryRole,
+		GTIDPrimaryDSN:            gtidPrimaryDSN,
+		StatusLikePatterns:        *collectStatusLikePatterns,
+		MaxMySQLConns:             *mysqlMaxconns,
+		MaxIdleConns:              *mysqlMaxIdleConns,
+		ConnMaxLifetime:           *mysqlConnMaxLifetime,
+		ConnMaxIdleTime:           *mysqlConnMaxIdleTime,
+		MaxMetricsPerCollector:    *maxMetricsPerCollector,
+		StrictCollectors:          *strictCollectors,
+		Database:                  *database,
+		RefreshIntervals:          refreshIntervals,
 	}
 
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(collector.New(dsn, collect))
+	if err := prometheus.WrapRegistererWith(*constLabels, registry).Register(collector.New(dsn, collect)); err != nil {
+		log.Errorln("Error registering the exporter:", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	gatherers := prometheus.Gatherers{
 		prometheus.DefaultGatherer,
@@ -239,6 +722,21 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	h.ServeHTTP(w, r)
 }
 
+// healthHandler serves the status of the last scrape as JSON, with a 503
+// status code if MySQL was down or there was no scrape yet.
+func healthHandler(lastScrape func() collector.ScrapeStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := lastScrape()
+		w.Header().Set("Content-Type", "application/json")
+		if !status.Up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			log.Errorln("Error encoding health status:", err)
+		}
+	}
+}
+
 func main() {
 	log.AddFlags(kingpin.CommandLine)
 	kingpin.Version(version.Print("mysqld_exporter"))
@@ -256,7 +754,40 @@ func main() {
 		}
 	}
 
+	// The primary's DSN holds credentials, so unlike a flag it is not
+	// visible in the process list.
+	gtidPrimaryDSN = os.Getenv("GTID_PRIMARY_DATA_SOURCE_NAME")
+
+	if *socks5Proxy != "" {
+		collector.RegisterDialer(collector.SOCKS5Dialer(*socks5Proxy))
+		var err error
+		if dsn, err = collector.DialerDSN(dsn); err != nil {
+			log.Fatal(err)
+		}
+		if gtidPrimaryDSN != "" {
+			if gtidPrimaryDSN, err = collector.DialerDSN(gtidPrimaryDSN); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	if err := collector.ValidateConstLabels(*constLabels); err != nil {
+		log.Fatal(err)
+	}
+
+	var err error
+	if refreshIntervals, err = parseRefreshIntervals(*refreshIntervalFlags); err != nil {
+		log.Fatal(err)
+	}
+
+	if *database != "" {
+		if err := collector.CheckDatabase(dsn, *database); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	http.HandleFunc(*metricPath, prometheus.InstrumentHandlerFunc("metrics", handler))
+	http.HandleFunc("/health", healthHandler(collector.LastScrape))
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write(landingPage)
 	})
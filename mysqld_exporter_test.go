@@ -1,9 +1,15 @@
 package main
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/prometheus/mysqld_exporter/collector"
 )
 
 func TestParseMycnf(t *testing.T) {
@@ -98,3 +104,69 @@ func TestParseMycnf(t *testing.T) {
 		})
 	})
 }
+
+func TestParseRefreshIntervals(t *testing.T) {
+	convey.Convey("Various refresh intervals", t, func() {
+		convey.Convey("Valid intervals", func() {
+			intervals, err := parseRefreshIntervals(map[string]string{"info_schema.tables": "5m"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(intervals, convey.ShouldResemble, map[string]time.Duration{"collect.info_schema.tables": 5 * time.Minute})
+		})
+		convey.Convey("Invalid interval", func() {
+			_, err := parseRefreshIntervals(map[string]string{"info_schema.tables": "often"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Non-positive interval", func() {
+			_, err := parseRefreshIntervals(map[string]string{"info_schema.tables": "0s"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Unknown collector", func() {
+			_, err := parseRefreshIntervals(map[string]string{"info_schema.table": "5m"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Collector option", func() {
+			_, err := parseRefreshIntervals(map[string]string{"info_schema.tables.databases": "5m"})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestHealthHandler(t *testing.T) {
+	convey.Convey("Health of the last scrape", t, func() {
+		scrapeTime := time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC)
+
+		convey.Convey("MySQL up", func() {
+			handler := healthHandler(func() collector.ScrapeStatus {
+				return collector.ScrapeStatus{
+					Time:       scrapeTime,
+					Up:         true,
+					Collectors: map[string]bool{"collect.global_status": true, "collect.slave_status": false},
+				}
+			})
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("GET", "/health", nil))
+
+			convey.So(rec.Code, convey.ShouldEqual, http.StatusOK)
+			convey.So(rec.Header().Get("Content-Type"), convey.ShouldEqual, "application/json")
+			var body map[string]interface{}
+			convey.So(json.Unmarshal(rec.Body.Bytes(), &body), convey.ShouldBeNil)
+			convey.So(body, convey.ShouldResemble, map[string]interface{}{
+				"last_scrape": "2017-07-14T02:40:00Z",
+				"mysql_up":    true,
+				"collectors":  map[string]interface{}{"collect.global_status": true, "collect.slave_status": false},
+			})
+		})
+		convey.Convey("MySQL down", func() {
+			handler := healthHandler(func() collector.ScrapeStatus {
+				return collector.ScrapeStatus{Time: scrapeTime, Collectors: map[string]bool{}}
+			})
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("GET", "/health", nil))
+
+			convey.So(rec.Code, convey.ShouldEqual, http.StatusServiceUnavailable)
+			var body map[string]interface{}
+			convey.So(json.Unmarshal(rec.Body.Bytes(), &body), convey.ShouldBeNil)
+			convey.So(body["mysql_up"], convey.ShouldEqual, false)
+		})
+	})
+}